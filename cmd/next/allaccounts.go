@@ -0,0 +1,104 @@
+package next
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/fatih/color"
+	"github.com/reitmas32/next/internal/config"
+	"github.com/spf13/cobra"
+)
+
+// accountSelection indica sobre qué cuentas trabaja list o versions
+type accountSelection struct {
+	// Account es la cuenta indicada con --account
+	Account string
+	// All consulta todas las cuentas configuradas
+	All bool
+	// Domain consulta todas las cuentas de un dominio
+	Domain string
+}
+
+// addAccountSelectionFlags agrega --account, --all-accounts y --domain a un comando
+func addAccountSelectionFlags(cmd *cobra.Command, sel *accountSelection) {
+	cmd.Flags().StringVarP(&sel.Account, "account", "a", "", "Nombre de la cuenta a usar")
+	cmd.Flags().BoolVar(&sel.All, "all-accounts", false, "Consultar todas las cuentas configuradas")
+	cmd.Flags().StringVar(&sel.Domain, "domain", "", "Consultar todas las cuentas de un dominio (ej: github.com)")
+	cmd.MarkFlagsMutuallyExclusive("account", "all-accounts")
+	cmd.MarkFlagsMutuallyExclusive("account", "domain")
+}
+
+// multiple indica si la consulta se reparte entre varias cuentas
+func (s accountSelection) multiple() bool {
+	return s.All || s.Domain != ""
+}
+
+// accounts retorna las cuentas seleccionadas con --all-accounts o --domain
+func (s accountSelection) accounts(cfg *config.Config) ([]*config.Account, error) {
+	if len(cfg.Accounts) == 0 {
+		return nil, fmt.Errorf("no hay cuentas configuradas. Use 'next login' para agregar una")
+	}
+
+	domain := normalizeAccountDomain(s.Domain)
+	var accounts []*config.Account
+	for i := range cfg.Accounts {
+		acc := &cfg.Accounts[i]
+		if domain != "" && normalizeAccountDomain(acc.Domain) != domain {
+			continue
+		}
+		accounts = append(accounts, acc)
+	}
+
+	if len(accounts) == 0 {
+		return nil, fmt.Errorf("no hay cuentas configuradas para el dominio %s", domain)
+	}
+	return accounts, nil
+}
+
+// forEachAccount ejecuta fn para cada cuenta de forma concurrente y retorna
+// el error de cada una en el mismo orden (nil si terminó bien)
+func forEachAccount(accounts []*config.Account, fn func(i int, acc *config.Account) error) []error {
+	errs := make([]error, len(accounts))
+
+	var wg sync.WaitGroup
+	for i, acc := range accounts {
+		wg.Add(1)
+		go func(i int, acc *config.Account) {
+			defer wg.Done()
+			errs[i] = fn(i, acc)
+		}(i, acc)
+	}
+	wg.Wait()
+
+	return errs
+}
+
+// printAccountFailures muestra las cuentas que fallaron. Retorna un error si
+// fallaron todas, para que el comando termine con error.
+func printAccountFailures(accounts []*config.Account, errs []error) error {
+	failed := 0
+	for i, err := range errs {
+		if err == nil {
+			continue
+		}
+		failed++
+		color.Yellow("! cuenta %s: %v", accounts[i].Name, err)
+	}
+
+	if failed > 0 && failed == len(accounts) {
+		return fmt.Errorf("fallaron todas las cuentas consultadas")
+	}
+	return nil
+}
+
+// sortedAccountNames retorna los nombres de las cuentas ordenados y separados por coma
+func sortedAccountNames(accounts []*config.Account) string {
+	names := make([]string, len(accounts))
+	for i, acc := range accounts {
+		names[i] = acc.Name
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,77 @@
+package next
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/reitmas32/next/internal/api"
+	"github.com/reitmas32/next/internal/cache"
+	"github.com/reitmas32/next/internal/config"
+	"github.com/spf13/cobra"
+)
+
+// defaultAPICacheTTL es la vigencia de las consultas a la API si la
+// configuración no indica otra (cache_ttl)
+const defaultAPICacheTTL = 10 * time.Minute
+
+// apiCacheOptions controla el cache de consultas de list y versions
+type apiCacheOptions struct {
+	// Offline sirve solo desde el cache
+	Offline bool
+	// Refresh ignora el cache
+	Refresh bool
+	// TTL reemplaza la vigencia configurada (0: usar la configuración)
+	TTL time.Duration
+}
+
+// addAPICacheFlags agrega --offline, --refresh y --cache-ttl a un comando
+func addAPICacheFlags(cmd *cobra.Command, opts *apiCacheOptions) {
+	cmd.Flags().BoolVar(&opts.Offline, "offline", false, "Usar solo el cache, sin consultar la red")
+	cmd.Flags().BoolVar(&opts.Refresh, "refresh", false, "Ignorar el cache y consultar de nuevo")
+	cmd.Flags().DurationVar(&opts.TTL, "cache-ttl", 0, "Vigencia del cache (ej: 30m; por defecto cache_ttl de la configuración o 10m)")
+	cmd.MarkFlagsMutuallyExclusive("offline", "refresh")
+}
+
+// apiCacheTTL retorna la vigencia del cache: el flag, la configuración o el valor por defecto
+func apiCacheTTL(cfg *config.Config, opts apiCacheOptions) (time.Duration, error) {
+	if opts.TTL > 0 {
+		return opts.TTL, nil
+	}
+	if cfg.CacheTTL == "" {
+		return defaultAPICacheTTL, nil
+	}
+
+	ttl, err := time.ParseDuration(cfg.CacheTTL)
+	if err != nil || ttl < 0 {
+		return 0, fmt.Errorf("cache_ttl inválido en la configuración: %q", cfg.CacheTTL)
+	}
+	return ttl, nil
+}
+
+// newCachedProvider crea el cliente de una cuenta cuyas consultas pasan por
+// el cache en ~/.next/cache/api
+func newCachedProvider(cfg *config.Config, account *config.Account, opts apiCacheOptions) (api.Provider, error) {
+	ttl, err := apiCacheTTL(cfg, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	store, err := cache.Open("api")
+	if err != nil {
+		return nil, err
+	}
+
+	provider, err := api.NewProvider(account.Provider, account.Domain, account.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	api.SetTransport(provider, &cache.Transport{
+		Store:   store,
+		Account: account.Name,
+		TTL:     ttl,
+		Offline: opts.Offline,
+		Refresh: opts.Refresh,
+	})
+	return provider, nil
+}
@@ -1,18 +1,30 @@
 package next
 
 import (
-	"bufio"
 	"fmt"
+	"io"
 	"os"
-	"os/exec"
-	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/fatih/color"
 	"github.com/reitmas32/next/internal/config"
+	"github.com/reitmas32/next/internal/credentials"
+	"github.com/reitmas32/next/internal/git"
+	"github.com/reitmas32/next/internal/goenv"
 	"github.com/spf13/cobra"
 )
 
+var (
+	checkUndo       bool
+	checkClean      bool
+	checkScope      string
+	checkTarget     string
+	checkGoAuthOnly bool
+)
+
 var checkCmd = &cobra.Command{
 	Use:   "check",
 	Short: "Verifica y configura dependencias privadas del proyecto",
@@ -20,15 +32,61 @@ var checkCmd = &cobra.Command{
 privadas y configura automáticamente GOPRIVATE y las credenciales 
 necesarias para que 'go mod tidy' funcione correctamente.
 
+Si existe un go.work en el directorio actual o en alguno superior, se
+analizan las dependencias de todos los módulos incluidos con 'use'.
+
 Soporta múltiples cuentas del mismo dominio (ej: GitHub personal y trabajo).
 Usa el owner del módulo para seleccionar la cuenta correcta.
 
-Ejemplo:
-  next check`,
+Los import paths vanity (ej: go.company.dev/libs/foo) se resuelven con las
+meta tags go-import y go-source, igual que el comando go; la cuenta y las
+credenciales se eligen según el repositorio real. Solo se consultan los
+dominios sin cuenta configurada que no son hosts públicos conocidos
+(golang.org, gopkg.in, k8s.io, ...), en paralelo. Las resoluciones se
+guardan 24h en ~/.next/cache/vanity.json y los errores de red 1h.
+
+Por defecto los patrones de GOPRIVATE se limitan al owner de cada dependencia
+(ej: github.com/mi-empresa) para no desactivar el checksum database y el
+proxy para todos los módulos públicos del dominio. Use --scope para elegir
+la granularidad: domain (github.com), owner o module (path exacto).
+
+Con Go 1.24+ también se agrega 'next goauth' a GOAUTH, que entrega los
+headers Authorization para el proxy y las peticiones go-get=1 sin tocar la
+configuración de git. Los clones directos con git siguen usando el credential
+helper; use --goauth-only si solo descarga módulos a través de un proxy.
+
+GOPRIVATE, GONOSUMDB, GONOPROXY y GOINSECURE se combinan con los valores
+existentes del archivo de 'go env -w' en lugar de sobrescribirlos (los
+valores exportados en el shell no se guardan). Los patrones agregados por
+next se registran en ~/.next/goenv.json y se pueden revertir con --undo.
+
+Cada clave de git config y entrada de .netrc escrita se registra en
+~/.next/credentials.json. --clean elimina todas esas entradas y además
+revierte los cambios de go env (equivale a --undo).
+
+--target elige dónde se escribe la configuración de git:
+  global  ~/.gitconfig (default)
+  repo    .git/config del repositorio actual
+  env     no escribe archivos: imprime exports GIT_CONFIG_COUNT/KEY_n/VALUE_n,
+          GOPRIVATE (y GOAUTH) para usar con eval en CI
+
+Ejemplos:
+  next check
+  next check --scope module
+  next check --target repo
+  eval "$(next check --target env)"
+  next check --undo
+  next check --clean`,
 	RunE: runCheck,
 }
 
 func init() {
+	checkCmd.Flags().StringVar(&checkScope, "scope", "owner", "Granularidad de los patrones de GOPRIVATE: domain, owner, module")
+	checkCmd.Flags().StringVar(&checkTarget, "target", "global", "Destino de la configuración de git: global, repo, env")
+	checkCmd.Flags().BoolVar(&checkGoAuthOnly, "goauth-only", false, "Configurar solo GOAUTH, sin credential helper de git (requiere Go 1.24+)")
+	checkCmd.Flags().BoolVar(&checkClean, "clean", false, "Eliminar todas las credenciales y variables de go env configuradas por next")
+	checkCmd.Flags().BoolVar(&checkUndo, "undo", false, "Eliminar solo los patrones de GOPRIVATE/GONOSUMDB/GONOPROXY/GOINSECURE/GOAUTH agregados por next")
+
 	rootCmd.AddCommand(checkCmd)
 }
 
@@ -38,24 +96,51 @@ func runCheck(cmd *cobra.Command, args []string) error {
 	yellow := color.New(color.FgYellow)
 	gray := color.New(color.FgWhite)
 
+	if checkClean {
+		return runCheckClean()
+	}
+
+	if checkUndo {
+		return runCheckUndo()
+	}
+
+	if checkScope != "domain" && checkScope != "owner" && checkScope != "module" {
+		return fmt.Errorf("scope inválido: %s (use 'domain', 'owner' o 'module')", checkScope)
+	}
+	if checkTarget != "global" && checkTarget != "repo" && checkTarget != "env" {
+		return fmt.Errorf("target inválido: %s (use 'global', 'repo' o 'env')", checkTarget)
+	}
+	patternScope, target := checkScope, checkTarget
+
+	// En modo env solo los exports van a stdout para poder usar eval
+	stdout := os.Stdout
+	if target == "env" {
+		colorOutput := color.Output
+		os.Stdout = os.Stderr
+		color.Output = os.Stderr
+		defer func() {
+			os.Stdout = stdout
+			color.Output = colorOutput
+		}()
+	}
+
 	fmt.Println()
 	cyan.Println("🔍 Analizando dependencias del proyecto...")
 	fmt.Println()
 
-	// Buscar go.mod en el directorio actual
-	goModPath := "go.mod"
-	if _, err := os.Stat(goModPath); os.IsNotExist(err) {
-		color.Red("✗ No se encontró go.mod en el directorio actual")
-		return fmt.Errorf("go.mod no encontrado")
-	}
-
-	// Leer dependencias del go.mod
-	dependencies, err := parseGoMod(goModPath)
+	// Buscar go.work (subiendo directorios) o go.mod en el directorio actual
+	project, err := loadProject()
 	if err != nil {
-		color.Red("✗ Error al leer go.mod: %v", err)
+		color.Red("✗ %v", err)
 		return err
 	}
 
+	if project.Workspace != nil {
+		gray.Printf("Workspace: %s (%d módulos)\n", project.Workspace.Path, len(project.Workspace.Modules))
+	}
+
+	dependencies := project.Dependencies
+
 	if len(dependencies) == 0 {
 		yellow.Println("No se encontraron dependencias en go.mod")
 		return nil
@@ -71,33 +156,7 @@ func runCheck(cmd *cobra.Command, args []string) error {
 	}
 
 	// Detectar dependencias privadas usando GetAccountForModule
-	var privateDeps []privateDependency
-	var goprivatePatterns []string
-	configuredDomains := make(map[string]bool)
-
-	for _, dep := range dependencies {
-		// Usar GetAccountForModule para encontrar la cuenta correcta
-		account, err := cfg.GetAccountForModule(dep)
-		if err != nil {
-			continue // No hay cuenta para este módulo
-		}
-
-		domain := extractDomain(dep)
-		owner := extractOwner(dep)
-
-		privateDeps = append(privateDeps, privateDependency{
-			Module:  dep,
-			Domain:  domain,
-			Owner:   owner,
-			Account: account,
-		})
-
-		// Agregar patrón a GOPRIVATE
-		if !configuredDomains[domain] {
-			goprivatePatterns = append(goprivatePatterns, domain+"/*")
-			configuredDomains[domain] = true
-		}
-	}
+	privateDeps, goprivatePatterns, goinsecurePatterns := resolvePrivateDependencies(cfg, dependencies, patternScope)
 
 	if len(privateDeps) == 0 {
 		green.Println("✔ No se detectaron dependencias privadas")
@@ -110,6 +169,9 @@ func runCheck(cmd *cobra.Command, args []string) error {
 
 	for _, dep := range privateDeps {
 		cyan.Printf("  • %s\n", dep.Module)
+		if dep.Repo != "" {
+			gray.Printf("    repositorio: %s (vanity)\n", dep.Repo)
+		}
 		if len(dep.Account.Owners) > 0 {
 			gray.Printf("    cuenta: %s (owners: %s)\n", dep.Account.Name, strings.Join(dep.Account.Owners, ", "))
 		} else {
@@ -118,20 +180,70 @@ func runCheck(cmd *cobra.Command, args []string) error {
 	}
 	fmt.Println()
 
-	// Configurar GOPRIVATE
-	goprivateValue := strings.Join(goprivatePatterns, ",")
+	if target == "env" {
+		return printCheckEnv(stdout, privateDeps, goprivatePatterns, goinsecurePatterns)
+	}
+
+	// En modo repo la configuración de git va al .git/config del repositorio
+	gitConfigFile := ""
+	if target == "repo" {
+		gitConfigFile, err = git.GetLocalConfigPath()
+		if err != nil {
+			color.Red("✗ --target repo requiere estar en un repositorio Git")
+			return err
+		}
+		gray.Printf("Configuración de git: %s\n\n", gitConfigFile)
+	}
+
+	// Configurar GOPRIVATE (y GONOSUMDB/GONOPROXY/GOINSECURE) sin pisar valores existentes
 	cyan.Println("⚙️  Configurando GOPRIVATE...")
 
-	if err := setGOPRIVATE(goprivateValue); err != nil {
+	result, err := goenv.Apply(goprivatePatterns, goinsecurePatterns)
+	if err != nil {
 		color.Red("✗ Error al configurar GOPRIVATE: %v", err)
 		return err
 	}
-	green.Printf("✔ GOPRIVATE=%s\n\n", goprivateValue)
+	printGoEnvResult(result)
+	fmt.Println()
+
+	// Configurar GOAUTH si el toolchain lo soporta
+	goauthConfigured := false
+	if goenv.SupportsGOAUTH() {
+		cyan.Println("🔑 Configurando GOAUTH...")
+
+		if value, err := configureGoAuth(); err != nil {
+			color.Yellow("! Advertencia al configurar GOAUTH: %v", err)
+		} else {
+			green.Printf("✔ GOAUTH=%s\n", value)
+			goauthConfigured = true
+		}
+		fmt.Println()
+	} else if checkGoAuthOnly {
+		color.Red("✗ El toolchain de Go instalado no soporta GOAUTH (requiere Go 1.24+)")
+		return fmt.Errorf("GOAUTH no soportado")
+	}
+
+	if checkGoAuthOnly && goauthConfigured {
+		green.Println("✔ Configuración completada (solo GOAUTH)")
+		fmt.Println()
+		return nil
+	}
 
 	// Configurar credenciales de git para cada dependencia
 	cyan.Println("🔐 Configurando credenciales de git...")
 
-	// Agrupar por dominio+cuenta para no configurar múltiples veces
+	// Cada clave de git config y entrada de .netrc escrita queda registrada en
+	// ~/.next/credentials.json para poder revertirla con --clean o 'next logout'
+	manifest, err := credentials.LoadManifest()
+	if err != nil {
+		color.Red("✗ %v", err)
+		return err
+	}
+
+	// El helper se instala una vez por dominio; elige la cuenta por path en cada petición
+	domainErrors := make(map[string]error)
+	domainArtifacts := make(map[string][]credentials.Artifact)
+	configuredDomains := make(map[string]bool)
 	configuredCredentials := make(map[string]bool)
 
 	for _, dep := range privateDeps {
@@ -139,13 +251,26 @@ func runCheck(cmd *cobra.Command, args []string) error {
 		if configuredCredentials[key] {
 			continue
 		}
+		configuredCredentials[key] = true
+
+		if !configuredDomains[dep.Domain] {
+			configuredDomains[dep.Domain] = true
+			domainArtifacts[dep.Domain], domainErrors[dep.Domain] = configureGitCredentials(gitConfigFile, dep.Domain, dep.Account)
+		}
 
-		if err := configureGitCredentials(dep.Domain, dep.Account); err != nil {
+		for _, a := range domainArtifacts[dep.Domain] {
+			manifest.Record(a, dep.Account.Name)
+		}
+
+		if err := domainErrors[dep.Domain]; err != nil {
 			color.Yellow("! Advertencia al configurar %s: %v", dep.Domain, err)
 		} else {
 			green.Printf("✔ Credenciales configuradas para %s (cuenta: %s)\n", dep.Domain, dep.Account.Name)
 		}
-		configuredCredentials[key] = true
+	}
+
+	if err := manifest.Save(); err != nil {
+		color.Yellow("! No se pudo guardar el manifiesto de credenciales: %v", err)
 	}
 
 	fmt.Println()
@@ -160,58 +285,160 @@ func runCheck(cmd *cobra.Command, args []string) error {
 }
 
 type privateDependency struct {
-	Module  string
+	Module string
+	// Domain y Owner son los del repositorio real (difieren de Module si es vanity)
 	Domain  string
 	Owner   string
+	Repo    string
 	Account *config.Account
 }
 
-// parseGoMod lee el archivo go.mod y extrae las dependencias
-func parseGoMod(path string) ([]string, error) {
-	file, err := os.Open(path)
-	if err != nil {
-		return nil, err
+// resolvePrivateDependencies selecciona la cuenta de cada dependencia con
+// GetAccountForModule y genera los patrones de GOPRIVATE y GOINSECURE
+func resolvePrivateDependencies(cfg *config.Config, dependencies []string, patternScope string) ([]privateDependency, []string, []string) {
+	var privateDeps []privateDependency
+	var goprivatePatterns []string
+	var goinsecurePatterns []string
+	configuredPatterns := make(map[string]bool)
+
+	prefetchVanity(cfg, dependencies)
+
+	for _, dep := range dependencies {
+		// Usar GetAccountForModule (resolviendo paths vanity) para encontrar la cuenta correcta
+		account, location, err := accountForModule(cfg, dep)
+		if err != nil {
+			continue // No hay cuenta para este módulo
+		}
+
+		privateDeps = append(privateDeps, privateDependency{
+			Module:  dep,
+			Domain:  location.Domain,
+			Owner:   location.Owner,
+			Repo:    location.Repo,
+			Account: account,
+		})
+
+		// Agregar patrón a GOPRIVATE según el scope
+		pattern := goprivatePattern(dep, patternScope)
+		if !configuredPatterns[pattern] {
+			goprivatePatterns = append(goprivatePatterns, pattern)
+			if strings.HasPrefix(account.Domain, "http://") {
+				goinsecurePatterns = append(goinsecurePatterns, pattern)
+			}
+			configuredPatterns[pattern] = true
+		}
 	}
-	defer file.Close()
 
-	var dependencies []string
-	inRequireBlock := false
+	return privateDeps, goprivatePatterns, goinsecurePatterns
+}
 
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		line := strings.TrimSpace(scanner.Text())
+// gitConfigEntry es un par clave/valor de configuración de git
+type gitConfigEntry struct {
+	Key   string
+	Value string
+}
 
-		// Detectar inicio de bloque require
-		if strings.HasPrefix(line, "require (") {
-			inRequireBlock = true
+// credentialHelperEntries retorna la configuración de git que instala el
+// credential helper para cada dominio de las dependencias privadas
+func credentialHelperEntries(privateDeps []privateDependency) ([]gitConfigEntry, error) {
+	helper, err := credentialHelperCommand()
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []gitConfigEntry
+	seen := make(map[string]bool)
+	for _, dep := range privateDeps {
+		if seen[dep.Domain] {
 			continue
 		}
+		seen[dep.Domain] = true
+
+		section := "credential." + credentialURL(dep.Domain, dep.Account)
+		entries = append(entries,
+			gitConfigEntry{Key: section + ".helper", Value: ""},
+			gitConfigEntry{Key: section + ".helper", Value: helper},
+			gitConfigEntry{Key: section + ".useHttpPath", Value: "true"},
+		)
+	}
 
-		// Detectar fin de bloque
-		if inRequireBlock && line == ")" {
-			inRequireBlock = false
-			continue
+	return entries, nil
+}
+
+// gitConfigEnv convierte entradas de configuración de git en variables
+// GIT_CONFIG_COUNT/GIT_CONFIG_KEY_n/GIT_CONFIG_VALUE_n, a continuación de
+// las que ya existan en el entorno
+func gitConfigEnv(entries []gitConfigEntry) []string {
+	offset, _ := strconv.Atoi(os.Getenv("GIT_CONFIG_COUNT"))
+
+	env := []string{fmt.Sprintf("GIT_CONFIG_COUNT=%d", offset+len(entries))}
+	for i, e := range entries {
+		env = append(env,
+			fmt.Sprintf("GIT_CONFIG_KEY_%d=%s", offset+i, e.Key),
+			fmt.Sprintf("GIT_CONFIG_VALUE_%d=%s", offset+i, e.Value),
+		)
+	}
+	return env
+}
+
+// printCheckEnv imprime los exports de --target env sin escribir archivos
+func printCheckEnv(w io.Writer, privateDeps []privateDependency, goprivatePatterns, goinsecurePatterns []string) error {
+	green := color.New(color.FgGreen)
+
+	env, err := credentialEnv(privateDeps, goprivatePatterns, goinsecurePatterns, !checkGoAuthOnly)
+	if err != nil {
+		color.Red("✗ %v", err)
+		return err
+	}
+
+	for _, e := range env {
+		key, value, _ := strings.Cut(e, "=")
+		fmt.Fprintf(w, "export %s=%s\n", key, shellQuote(value))
+	}
+
+	green.Println("✔ Exports generados (no se modificó ningún archivo)")
+	return nil
+}
+
+// credentialEnv genera las variables de entorno (KEY=VALUE) equivalentes a lo
+// que 'next check' escribiría: GOPRIVATE y relacionadas, GOAUTH si el toolchain
+// lo soporta y, si withGit, la configuración de git del credential helper
+func credentialEnv(privateDeps []privateDependency, goprivatePatterns, goinsecurePatterns []string, withGit bool) ([]string, error) {
+	result, _, err := goenv.Plan(goprivatePatterns, goinsecurePatterns)
+	if err != nil {
+		return nil, fmt.Errorf("error al calcular GOPRIVATE: %w", err)
+	}
+
+	var env []string
+	for _, key := range []string{goenv.GOPRIVATE, goenv.GONOSUMDB, goenv.GONOPROXY, goenv.GOINSECURE} {
+		if key == goenv.GOPRIVATE || len(result.Added[key]) > 0 {
+			env = append(env, key+"="+result.Values[key])
 		}
+	}
 
-		// Require en una línea
-		if strings.HasPrefix(line, "require ") && !strings.Contains(line, "(") {
-			parts := strings.Fields(line)
-			if len(parts) >= 2 {
-				dependencies = append(dependencies, parts[1])
+	if goenv.SupportsGOAUTH() {
+		command, err := goauthCommand()
+		if err == nil {
+			if value, err := goenv.PlanGOAUTH(command); err == nil {
+				env = append(env, goenv.GOAUTH+"="+value)
 			}
-			continue
 		}
+	}
 
-		// Dependencias dentro del bloque
-		if inRequireBlock && line != "" && !strings.HasPrefix(line, "//") {
-			parts := strings.Fields(line)
-			if len(parts) >= 1 {
-				dependencies = append(dependencies, parts[0])
-			}
+	if withGit {
+		entries, err := credentialHelperEntries(privateDeps)
+		if err != nil {
+			return nil, fmt.Errorf("error al generar configuración de git: %w", err)
 		}
+		env = append(env, gitConfigEnv(entries)...)
 	}
 
-	return dependencies, scanner.Err()
+	return env, nil
+}
+
+// shellQuote escapa un valor para sh entre comillas simples
+func shellQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
 }
 
 // extractDomain extrae el dominio de un módulo Go
@@ -232,55 +459,225 @@ func extractOwner(module string) string {
 	return ""
 }
 
-// setGOPRIVATE configura la variable GOPRIVATE
-func setGOPRIVATE(value string) error {
-	cmd := exec.Command("go", "env", "-w", "GOPRIVATE="+value)
-	return cmd.Run()
+// goprivatePattern genera el patrón de GOPRIVATE más estrecho para el scope indicado
+// Ejemplo: "github.com/mi-empresa/core-lib" -> "github.com/mi-empresa" (owner).
+// Un prefijo sin comodín cubre todos sus subpaths; "dominio/owner/*" no
+// cubriría el módulo "dominio/owner" (ej: paths vanity de dos elementos).
+func goprivatePattern(module, scope string) string {
+	domain := extractDomain(module)
+	owner := extractOwner(module)
+
+	switch {
+	case scope == "module":
+		return module
+	case scope == "owner" && owner != "":
+		return domain + "/" + owner
+	default:
+		return domain
+	}
 }
 
-// configureGitCredentials configura las credenciales de git para un dominio
-func configureGitCredentials(domain string, account *config.Account) error {
-	// Configurar git para usar el token
-	var urlPattern string
-	if account.Provider == "github" {
-		urlPattern = fmt.Sprintf("url.https://%s:x-oauth-basic@%s/.insteadOf", account.Token, domain)
-	} else {
-		// GitLab usa oauth2 como username
-		urlPattern = fmt.Sprintf("url.https://oauth2:%s@%s/.insteadOf", account.Token, domain)
+// runCheckClean elimina las credenciales registradas en el manifiesto y
+// revierte los cambios de go env
+func runCheckClean() error {
+	green := color.New(color.FgGreen)
+	yellow := color.New(color.FgYellow)
+	gray := color.New(color.FgWhite)
+
+	manifest, err := credentials.LoadManifest()
+	if err != nil {
+		color.Red("✗ %v", err)
+		return err
 	}
 
-	originalURL := fmt.Sprintf("https://%s/", domain)
+	removed, cleanErr := manifest.Clean()
+	if err := manifest.Save(); err != nil {
+		color.Red("✗ Error al guardar manifiesto de credenciales: %v", err)
+		return err
+	}
 
-	cmd := exec.Command("git", "config", "--global", urlPattern, originalURL)
-	if err := cmd.Run(); err != nil {
-		// Intentar con .netrc como fallback
-		return configureNetrc(domain, account)
+	fmt.Println()
+	if len(removed) == 0 {
+		yellow.Println("No hay credenciales registradas por next")
+	}
+	for _, a := range removed {
+		green.Printf("✔ Eliminado %s\n", describeArtifact(a))
+	}
+	if cleanErr != nil {
+		color.Yellow("! Algunas entradas no se pudieron eliminar: %v", cleanErr)
 	}
 
-	return nil
+	if err := runCheckUndo(); err != nil {
+		return err
+	}
+
+	gray.Println("  Las cuentas siguen configuradas; use 'next logout' para eliminarlas")
+	fmt.Println()
+
+	return cleanErr
 }
 
-// configureNetrc configura el archivo .netrc como fallback
-func configureNetrc(domain string, account *config.Account) error {
-	homeDir, err := os.UserHomeDir()
+// describeArtifact retorna una descripción legible de un artefacto
+func describeArtifact(a credentials.Artifact) string {
+	if a.Kind == credentials.KindNetrc {
+		return fmt.Sprintf(".netrc: machine %s login %s", a.Key, a.Login)
+	}
+	return "git config: " + a.Key
+}
+
+// configureGoAuth agrega 'next goauth' a GOAUTH conservando los métodos existentes
+func configureGoAuth() (string, error) {
+	command, err := goauthCommand()
+	if err != nil {
+		return "", err
+	}
+	return goenv.ApplyGOAUTH(command)
+}
+
+// printGoEnvResult muestra los valores finales y los patrones agregados
+func printGoEnvResult(result *goenv.Result) {
+	green := color.New(color.FgGreen)
+	gray := color.New(color.FgWhite)
+
+	for _, key := range []string{goenv.GOPRIVATE, goenv.GONOSUMDB, goenv.GONOPROXY, goenv.GOINSECURE} {
+		added := result.Added[key]
+		if key != goenv.GOPRIVATE && len(added) == 0 {
+			continue
+		}
+
+		green.Printf("✔ %s=%s\n", key, result.Values[key])
+		if len(added) > 0 {
+			gray.Printf("  agregados: %s\n", strings.Join(added, ", "))
+		}
+	}
+}
+
+// runCheckUndo elimina los patrones de go env agregados por next
+func runCheckUndo() error {
+	green := color.New(color.FgGreen)
+	yellow := color.New(color.FgYellow)
+	gray := color.New(color.FgWhite)
+
+	removed, err := goenv.Undo()
 	if err != nil {
+		color.Red("✗ Error al revertir configuración: %v", err)
 		return err
 	}
 
-	netrcPath := filepath.Join(homeDir, ".netrc")
+	fmt.Println()
+	if len(removed) == 0 {
+		yellow.Println("No hay patrones agregados por next para eliminar")
+		return nil
+	}
+
+	for _, key := range []string{goenv.GOPRIVATE, goenv.GONOSUMDB, goenv.GONOPROXY, goenv.GOINSECURE, goenv.GOAUTH} {
+		if len(removed[key]) == 0 {
+			continue
+		}
+		green.Printf("✔ %s: eliminados %s\n", key, strings.Join(removed[key], ", "))
+	}
+	gray.Println("  Los valores configurados por el usuario se conservaron")
+	fmt.Println()
+
+	return nil
+}
+
+// configureGitCredentials instala 'next credential' como credential helper del
+// dominio con credential.useHttpPath, de modo que el token nunca se escribe en
+// la configuración de git. file es el archivo de configuración (vacío para
+// ~/.gitconfig); solo en ese caso se usa .netrc como fallback si git falla.
+// Retorna los artefactos escritos.
+func configureGitCredentials(file, domain string, account *config.Account) ([]credentials.Artifact, error) {
+	// Eliminar entradas insteadOf con tokens escritas por versiones anteriores
+	// (solo en el archivo que se está configurando: --target repo no toca ~/.gitconfig)
+	removeLegacyInsteadOf(file, domain)
+
+	entries, err := credentialHelperEntries([]privateDependency{{Domain: domain, Account: account}})
+	if err != nil {
+		if file != "" {
+			return nil, err
+		}
+		return configureNetrc(domain, account)
+	}
+
+	// Valores por clave, en el orden en que se escriben
+	var keys []string
+	values := make(map[string][]string)
+	for _, e := range entries {
+		if values[e.Key] == nil {
+			keys = append(keys, e.Key)
+		}
+		values[e.Key] = append(values[e.Key], e.Value)
+	}
+
+	var artifacts []credentials.Artifact
+	for _, key := range keys {
+		// Se guardan los valores previos (ej: el helper de 'gh auth setup-git')
+		// para restaurarlos con 'next check --clean' o 'next logout'
+		previous, err := git.GetConfigAll(file, key)
+		if err == nil && slices.Equal(previous, values[key]) {
+			// Ya estaba configurado por next
+			previous = nil
+		}
+
+		for i, value := range values[key] {
+			if err != nil {
+				break
+			}
+			if i == 0 {
+				err = git.SetConfig(file, key, value)
+			} else {
+				err = git.AddConfig(file, key, value)
+			}
+		}
+
+		if err != nil {
+			if len(artifacts) == 0 && file == "" {
+				return configureNetrc(domain, account)
+			}
+			return artifacts, err
+		}
+
+		artifacts = append(artifacts, credentials.Artifact{Kind: credentials.KindGitConfig, Key: key, File: file, Previous: previous})
+	}
+
+	return artifacts, nil
+}
+
+// credentialHelperCommand retorna el valor de credential.helper que ejecuta este binario
+func credentialHelperCommand() (string, error) {
+	exe, err := os.Executable()
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("!\"%s\" credential", exe), nil
+}
+
+// credentialURL retorna la URL base del dominio respetando http:// si la cuenta lo usa
+func credentialURL(domain string, account *config.Account) string {
+	if strings.HasPrefix(account.Domain, "http://") {
+		return "http://" + domain
+	}
+	return "https://" + domain
+}
+
+// removeLegacyInsteadOf elimina reglas url.<token>@dominio.insteadOf del
+// archivo de configuración (vacío para ~/.gitconfig)
+func removeLegacyInsteadOf(file, domain string) {
+	pattern := `^url\.https?://[^@]+@` + regexp.QuoteMeta(domain) + `/\.insteadof$`
 
-	// Leer contenido existente
-	var content string
-	if data, err := os.ReadFile(netrcPath); err == nil {
-		content = string(data)
+	entries, err := git.GetConfigRegexp(file, pattern)
+	if err != nil {
+		return
 	}
 
-	// Verificar si ya existe entrada para este dominio
-	if strings.Contains(content, "machine "+domain) {
-		return nil // Ya configurado
+	for key := range entries {
+		_ = git.UnsetConfig(file, key)
 	}
+}
 
-	// Agregar nueva entrada
+// configureNetrc configura el archivo .netrc como fallback
+func configureNetrc(domain string, account *config.Account) ([]credentials.Artifact, error) {
 	var username string
 	if account.Provider == "github" {
 		username = "x-oauth-basic"
@@ -288,14 +685,11 @@ func configureNetrc(domain string, account *config.Account) error {
 		username = "oauth2"
 	}
 
-	entry := fmt.Sprintf("\nmachine %s login %s password %s\n", domain, username, account.Token)
-
-	f, err := os.OpenFile(netrcPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
-	if err != nil {
-		return err
+	written, err := credentials.AddNetrcEntry(domain, username, account.Token)
+	if err != nil || !written {
+		// Si ya existía una entrada para el dominio no es de next
+		return nil, err
 	}
-	defer f.Close()
 
-	_, err = f.WriteString(entry)
-	return err
+	return []credentials.Artifact{{Kind: credentials.KindNetrc, Key: domain, Login: username}}, nil
 }
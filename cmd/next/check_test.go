@@ -0,0 +1,43 @@
+package next
+
+import (
+	"strings"
+	"testing"
+
+	"golang.org/x/mod/module"
+)
+
+func TestGoprivatePattern(t *testing.T) {
+	for _, tc := range []struct {
+		module, scope, want string
+	}{
+		{"github.com/mi-empresa/core-lib", "owner", "github.com/mi-empresa"},
+		{"github.com/mi-empresa/core-lib/v2", "owner", "github.com/mi-empresa"},
+		{"go.company.dev/retry", "owner", "go.company.dev/retry"},
+		{"go.company.dev", "owner", "go.company.dev"},
+		{"github.com/mi-empresa/core-lib", "domain", "github.com"},
+		{"github.com/mi-empresa/core-lib/v2", "module", "github.com/mi-empresa/core-lib/v2"},
+	} {
+		got := goprivatePattern(tc.module, tc.scope)
+		if got != tc.want {
+			t.Errorf("goprivatePattern(%q, %q) = %q, se esperaba %q", tc.module, tc.scope, got, tc.want)
+		}
+		// El patrón debe cubrir el módulo con la semántica del comando go
+		if !module.MatchPrefixPatterns(got, tc.module) {
+			t.Errorf("GOPRIVATE=%s no cubre %s", got, tc.module)
+		}
+	}
+}
+
+func TestMirrorPatternsCoverTwoElementModules(t *testing.T) {
+	modules := []string{"go.company.dev/retry", "github.com/mi-empresa/a", "github.com/mi-empresa/b"}
+	patterns := mirrorPatterns(modules)
+	if len(patterns) != 2 {
+		t.Fatalf("mirrorPatterns = %v, se esperaban 2 patrones", patterns)
+	}
+	for _, m := range modules {
+		if !module.MatchPrefixPatterns(strings.Join(patterns, ","), m) {
+			t.Errorf("GONOSUMDB=%v no cubre %s", patterns, m)
+		}
+	}
+}
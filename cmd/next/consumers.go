@@ -0,0 +1,204 @@
+package next
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/reitmas32/next/internal/api"
+	"github.com/reitmas32/next/internal/cache"
+	"github.com/reitmas32/next/internal/config"
+	"github.com/reitmas32/next/internal/gomod"
+)
+
+// scanCacheTTL es la vigencia de listados y go.mod guardados en el cache
+const scanCacheTTL = time.Hour
+
+// scanWorkers limita las descargas simultáneas de go.mod
+const scanWorkers = 8
+
+// scanOptions delimita los repositorios que se revisan
+type scanOptions struct {
+	// Account limita la búsqueda a una cuenta (vacío: todas)
+	Account string
+	// Owner limita la búsqueda a un usuario u organización
+	Owner string
+	// Refresh ignora el cache
+	Refresh bool
+	// Language pide el lenguaje principal de cada librería (search --language)
+	Language bool
+}
+
+// scannedRepo es un repositorio Go visible por una cuenta junto con su go.mod
+type scannedRepo struct {
+	Account *config.Account
+	Library api.Library
+	// Repo es host/path del repositorio (ej: github.com/org/service)
+	Repo  string
+	GoMod *gomod.File
+}
+
+// scanRepositories lista los repositorios Go que pueden ver las cuentas y lee
+// el go.mod de la rama por defecto de cada uno. Los errores de una cuenta o
+// repositorio no detienen la búsqueda; se retornan junto con los resultados.
+func scanRepositories(cfg *config.Config, opts scanOptions) ([]scannedRepo, []error) {
+	store, err := cache.Open("repos")
+	if err != nil {
+		return nil, []error{err}
+	}
+
+	catalog, errs := loadCatalog(store, cfg, opts)
+
+	repos := make([]scannedRepo, len(catalog))
+	for i, e := range catalog {
+		repos[i] = scannedRepo{Account: e.Account, Library: e.Library, Repo: e.Repo}
+	}
+
+	providers := newProviderPool()
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, scanWorkers)
+
+	for i := range repos {
+		wg.Add(1)
+		go func(r *scannedRepo) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			f, err := cachedGoMod(store, providers, r, opts.Refresh)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", r.Repo, err))
+				return
+			}
+			r.GoMod = f
+		}(&repos[i])
+	}
+	wg.Wait()
+
+	// Solo los repositorios con go.mod legible
+	var result []scannedRepo
+	for _, r := range repos {
+		if r.GoMod != nil {
+			result = append(result, r)
+		}
+	}
+	return result, errs
+}
+
+// catalogEntry es una librería Go visible por una cuenta
+type catalogEntry struct {
+	Account *config.Account
+	Library api.Library
+	// Repo es host/path del repositorio
+	Repo string
+	// Score es la relevancia en una búsqueda
+	Score int
+}
+
+// loadCatalog lista las librerías Go de las cuentas (usando el cache) sin
+// repetir repositorios que ven varias cuentas
+func loadCatalog(store *cache.Store, cfg *config.Config, opts scanOptions) ([]catalogEntry, []error) {
+	var accounts []*config.Account
+	for i := range cfg.Accounts {
+		acc := &cfg.Accounts[i]
+		if opts.Account != "" && acc.Name != opts.Account {
+			continue
+		}
+		if opts.Owner != "" && !acc.IsWildcard() && !acc.HasOwner(opts.Owner) {
+			continue
+		}
+		accounts = append(accounts, acc)
+	}
+	if len(accounts) == 0 {
+		return nil, []error{fmt.Errorf("no hay cuentas configuradas para la búsqueda")}
+	}
+
+	var catalog []catalogEntry
+	var errs []error
+	seen := make(map[string]bool)
+
+	for _, acc := range accounts {
+		libraries, err := cachedLibraries(store, acc, opts)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("cuenta %s: %w", acc.Name, err))
+			continue
+		}
+
+		domain := normalizeAccountDomain(acc.Domain)
+		for _, lib := range libraries {
+			repo := domain + "/" + lib.Path
+			if lib.Path == "" || seen[repo] {
+				continue
+			}
+			seen[repo] = true
+			catalog = append(catalog, catalogEntry{Account: acc, Library: lib, Repo: repo})
+		}
+	}
+	return catalog, errs
+}
+
+// cachedLibraries lista las librerías Go de una cuenta usando el cache
+func cachedLibraries(store *cache.Store, acc *config.Account, opts scanOptions) ([]api.Library, error) {
+	key := "libraries\n" + acc.Name + "\n" + opts.Owner
+	if opts.Language {
+		key += "\nlanguage"
+	}
+	if data, ok := store.Get(key, scanCacheTTL); ok && !opts.Refresh {
+		var libraries []api.Library
+		if err := json.Unmarshal(data, &libraries); err == nil {
+			return libraries, nil
+		}
+	}
+
+	provider, err := api.NewProvider(acc.Provider, acc.Domain, acc.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	libraries, err := provider.ListGoLibrariesWithOptions(api.ListOptions{Visibility: api.VisibilityAll, Owner: opts.Owner, WithLanguage: opts.Language})
+	if err != nil {
+		return nil, err
+	}
+
+	if data, err := json.Marshal(libraries); err == nil {
+		_ = store.Put(key, data)
+	}
+	return libraries, nil
+}
+
+// cachedGoMod lee el go.mod de la rama por defecto de un repositorio usando el cache
+func cachedGoMod(store *cache.Store, providers *providerPool, r *scannedRepo, refresh bool) (*gomod.File, error) {
+	key := "gomod\n" + r.Account.Name + "\n" + r.Repo
+	if data, ok := store.Get(key, scanCacheTTL); ok && !refresh {
+		return gomod.ParseData(data)
+	}
+
+	provider, err := providers.get(r.Account)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := provider.GetFile(r.Library.Path, "go.mod", "")
+	if errors.Is(err, api.ErrNotFound) {
+		return nil, fmt.Errorf("no tiene go.mod en la rama por defecto")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	_ = store.Put(key, data)
+	return gomod.ParseData(data)
+}
+
+// normalizeAccountDomain quita el esquema y la barra final del dominio de una cuenta
+func normalizeAccountDomain(domain string) string {
+	domain = strings.TrimPrefix(domain, "https://")
+	domain = strings.TrimPrefix(domain, "http://")
+	return strings.TrimSuffix(domain, "/")
+}
@@ -2,6 +2,8 @@ package next
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
 	"regexp"
 	"strings"
 
@@ -9,12 +11,16 @@ import (
 	"github.com/reitmas32/next/internal/api"
 	"github.com/reitmas32/next/internal/config"
 	"github.com/reitmas32/next/internal/git"
+	"github.com/reitmas32/next/internal/gomod"
+	"github.com/reitmas32/next/internal/modproxy"
+	"github.com/reitmas32/next/internal/sumdb"
 	"github.com/spf13/cobra"
 )
 
 var (
 	forceVersion bool
 	skipPush     bool
+	skipSums     bool
 )
 
 var createVersionCmd = &cobra.Command{
@@ -32,6 +38,14 @@ Validaciones:
 
 Soporta múltiples cuentas del mismo dominio (usa el owner del repo para seleccionar).
 
+En un workspace (go.work) se detecta el módulo del directorio actual. Si está
+en un subdirectorio del repositorio, el tag se prefija con ese subdirectorio
+(ej: libs/foo/v1.4.0). La versión se rechaza si el módulo requiere a otro
+módulo del workspace en una pseudo-versión (sin release).
+
+Después de crear el tag se registran sus hashes h1: en el ledger firmado
+(ver 'next record-sums'); use --skip-sums para omitirlo.
+
 Ejemplo:
   next create-version v1.4.0`,
 	Args: cobra.ExactArgs(1),
@@ -41,6 +55,7 @@ Ejemplo:
 func init() {
 	createVersionCmd.Flags().BoolVarP(&forceVersion, "force", "f", false, "Forzar creación aunque haya cambios sin commit")
 	createVersionCmd.Flags().BoolVar(&skipPush, "skip-push", false, "No hacer push automático de commits pendientes")
+	createVersionCmd.Flags().BoolVar(&skipSums, "skip-sums", false, "No registrar los hashes h1: de la versión en el ledger")
 }
 
 func runCreateVersion(cmd *cobra.Command, args []string) error {
@@ -58,7 +73,7 @@ func runCreateVersion(cmd *cobra.Command, args []string) error {
 	}
 
 	// Verificar que estamos en un repo git
-	_, err := git.GetRepoRoot()
+	repoRoot, err := git.GetRepoRoot()
 	if err != nil {
 		color.Red("✗ No se encuentra en un repositorio Git")
 		return err
@@ -78,6 +93,31 @@ func runCreateVersion(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	// Detectar el módulo del workspace que se está versionando (si hay go.work)
+	release, err := resolveWorkspaceRelease(repoRoot, tag)
+	if err != nil {
+		color.Red("✗ %v", err)
+		return err
+	}
+
+	if release != nil {
+		cyan.Printf("📦 Módulo del workspace: %s\n", release.ModulePath)
+
+		if len(release.Unreleased) > 0 {
+			color.Red("✗ El módulo requiere módulos hermanos del workspace sin versión publicada:")
+			for _, r := range release.Unreleased {
+				color.Red("    %s %s", r.Path, r.Version)
+			}
+			yellow.Println("  Publique primero esas versiones y actualice el require en go.mod")
+			if !forceVersion {
+				return fmt.Errorf("dependencias del workspace sin versión")
+			}
+			yellow.Println("  Continuando por -f (force)...")
+		}
+
+		tag = release.Tag
+	}
+
 	// Obtener remote origin
 	remoteURL, err := git.GetRemoteURL("origin")
 	if err != nil {
@@ -178,13 +218,81 @@ func runCreateVersion(cmd *cobra.Command, args []string) error {
 	fmt.Println()
 
 	// Mostrar cómo instalar
+	modulePath := fmt.Sprintf("%s/%s", domain, repoPath)
+	installVersion := tag
+	subdir := ""
+	if release != nil {
+		modulePath = release.ModulePath
+		installVersion = release.Version
+		subdir = release.Subdir
+	} else if f, err := gomod.Parse(filepath.Join(repoRoot, "go.mod")); err == nil {
+		modulePath = f.Module
+	}
+
+	// Registrar los hashes h1: de la nueva versión en el ledger
+	if !skipSums {
+		recordReleaseSums(modulePath, subdir, tag, installVersion)
+		fmt.Println()
+	}
+
 	color.White("Para instalar esta versión:")
-	cyan.Printf("  go get %s/%s@%s\n", domain, repoPath, tag)
+	cyan.Printf("  go get %s@%s\n", modulePath, installVersion)
 	fmt.Println()
 
 	return nil
 }
 
+// recordReleaseSums trae el tag recién creado y registra sus hashes en el
+// ledger. subdir es el prefijo del tag, que en un módulo /vN de un
+// subdirectorio vN es el directorio padre. Los errores solo se advierten: el
+// tag ya existe en el remote.
+func recordReleaseSums(modulePath, subdir, tag, version string) {
+	green := color.New(color.FgGreen)
+	yellow := color.New(color.FgYellow)
+
+	warn := func(err error) {
+		yellow.Printf("! No se registraron los hashes en el ledger: %v\n", err)
+		yellow.Printf("  Use: next record-sums %s@%s\n", modulePath, version)
+	}
+
+	if err := git.FetchTag("origin", tag); err != nil {
+		warn(err)
+		return
+	}
+
+	gitDir, err := git.GetGitCommonDir()
+	if err != nil {
+		warn(err)
+		return
+	}
+
+	path, err := ledgerPath("")
+	if err != nil {
+		warn(err)
+		return
+	}
+
+	ledger, _, err := openTrustedLedger(path)
+	if err != nil {
+		warn(err)
+		return
+	}
+
+	key, err := sumdb.LoadOrCreateKey()
+	if err != nil {
+		warn(err)
+		return
+	}
+
+	src := &modproxy.Source{Module: modulePath, Subdir: subdir, GitDir: gitDir}
+	_, hash, err := recordSourceSums(ledger, key, src, version)
+	if err != nil {
+		warn(err)
+		return
+	}
+	green.Printf("✔ Hash registrado en el ledger: %s\n", hash)
+}
+
 // isValidSemver valida que el tag siga el formato vX.Y.Z
 func isValidSemver(tag string) bool {
 	pattern := `^v\d+\.\d+\.\d+$`
@@ -201,3 +309,55 @@ func extractOwnerFromRepoPath(repoPath string) string {
 	}
 	return ""
 }
+
+// workspaceRelease describe el módulo de un workspace que se va a versionar
+type workspaceRelease struct {
+	ModulePath string
+	Version    string
+	Tag        string
+	// Subdir es el prefijo del tag sin la barra final (ej: lib para lib/v2.0.0)
+	Subdir     string
+	Unreleased []gomod.Require
+}
+
+// resolveWorkspaceRelease detecta el módulo del workspace que contiene el
+// directorio actual y calcula el tag completo. Retorna nil si no hay go.work.
+func resolveWorkspaceRelease(repoRoot, version string) (*workspaceRelease, error) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+
+	workPath, err := gomod.FindWorkspace(cwd)
+	if err != nil || workPath == "" {
+		return nil, err
+	}
+
+	ws, err := gomod.LoadWorkspace(workPath)
+	if err != nil {
+		return nil, fmt.Errorf("error al leer go.work: %w", err)
+	}
+
+	module := ws.ModuleForDir(cwd)
+	if module == nil {
+		return nil, fmt.Errorf("el directorio actual no pertenece a ningún módulo del workspace")
+	}
+
+	rel, err := filepath.Rel(repoRoot, module.Dir)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return nil, fmt.Errorf("el módulo %s no está dentro del repositorio actual", module.File.Module)
+	}
+
+	if err := gomod.CheckMajor(module.File.Module, version); err != nil {
+		return nil, err
+	}
+
+	prefix := gomod.TagPrefix(module.File.Module, filepath.ToSlash(rel))
+	return &workspaceRelease{
+		ModulePath: module.File.Module,
+		Version:    version,
+		Tag:        prefix + version,
+		Subdir:     strings.TrimSuffix(prefix, "/"),
+		Unreleased: ws.UnreleasedSiblings(module),
+	}, nil
+}
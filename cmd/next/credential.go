@@ -0,0 +1,89 @@
+package next
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/reitmas32/next/internal/config"
+	"github.com/reitmas32/next/internal/git"
+	"github.com/spf13/cobra"
+)
+
+var credentialCmd = &cobra.Command{
+	Use:   "credential <get|store|erase>",
+	Short: "Credential helper de git que usa las cuentas de next",
+	Long: `Implementa el protocolo de credential helpers de git (get/store/erase).
+
+Con credential.useHttpPath activado, git envía el path del repositorio y
+next elige la cuenta correcta con la misma lógica que 'next check'
+(owner específico primero, luego cuenta wildcard). Los tokens nunca se
+escriben en ~/.gitconfig ni en ~/.netrc.
+
+'next check' lo instala automáticamente por dominio:
+  [credential "https://github.com"]
+      helper =
+      helper = !"/usr/local/bin/next" credential
+      useHttpPath = true`,
+	Args:      cobra.ExactArgs(1),
+	ValidArgs: []string{"get", "store", "erase"},
+	RunE:      runCredential,
+}
+
+func init() {
+	rootCmd.AddCommand(credentialCmd)
+}
+
+func runCredential(cmd *cobra.Command, args []string) error {
+	request, err := git.ReadCredential(os.Stdin)
+	if err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "get":
+		return credentialGet(request, os.Stdout)
+	case "store", "erase":
+		// Los tokens se administran con 'next login'/'next logout'
+		return nil
+	default:
+		return fmt.Errorf("acción no soportada: %s (use get, store o erase)", args[0])
+	}
+}
+
+// credentialGet responde con el token de la cuenta que corresponde a la petición.
+// Si no hay cuenta no escribe nada para que git pruebe con el siguiente helper.
+func credentialGet(request *git.Credential, w io.Writer) error {
+	if request.Protocol != "https" && request.Protocol != "http" {
+		return nil
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+
+	account := accountForCredential(cfg, request)
+	if account == nil {
+		return nil
+	}
+
+	return git.WriteCredential(w, account.GitUsername(), account.Token)
+}
+
+// accountForCredential selecciona la cuenta por path del repositorio o,
+// si git no envió el path, por dominio
+func accountForCredential(cfg *config.Config, request *git.Credential) *config.Account {
+	if request.Path != "" {
+		if account, err := cfg.GetAccountForModule(request.ModulePath()); err == nil {
+			return account
+		}
+		return nil
+	}
+
+	account, err := cfg.GetAccountByDomain(request.Host)
+	if err != nil {
+		return nil
+	}
+	return account
+}
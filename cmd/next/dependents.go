@@ -0,0 +1,190 @@
+package next
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/reitmas32/next/internal/config"
+	"github.com/reitmas32/next/internal/gomod"
+	"github.com/spf13/cobra"
+)
+
+var (
+	dependentsOwner   string
+	dependentsAccount string
+	dependentsRefresh bool
+)
+
+var dependentsCmd = &cobra.Command{
+	Use:   "dependents <módulo>",
+	Short: "Busca los repositorios que dependen de un módulo",
+	Long: `Revisa todos los repositorios Go que pueden ver las cuentas configuradas,
+lee su go.mod (rama por defecto) con la API del proveedor y muestra cuáles
+requieren el módulo y en qué versión. También se muestran los consumidores de
+otras versiones mayores del módulo (/v2, /v3, ...).
+
+Los listados y go.mod se guardan en ~/.next/cache/repos durante una hora;
+use --refresh para consultarlos de nuevo.
+
+Ejemplos:
+  next dependents github.com/mi-empresa/core-lib
+  next dependents github.com/mi-empresa/core-lib --owner mi-empresa
+  next dependents github.com/mi-empresa/core-lib --account work --refresh`,
+	Args: cobra.ExactArgs(1),
+	RunE: runDependents,
+}
+
+func init() {
+	dependentsCmd.Flags().StringVarP(&dependentsOwner, "owner", "o", "", "Solo repositorios de este usuario u organización")
+	dependentsCmd.Flags().StringVarP(&dependentsAccount, "account", "a", "", "Solo repositorios visibles por esta cuenta")
+	dependentsCmd.Flags().BoolVar(&dependentsRefresh, "refresh", false, "Ignorar el cache y consultar de nuevo")
+
+	rootCmd.AddCommand(dependentsCmd)
+}
+
+// dependent es un repositorio que requiere el módulo buscado
+type dependent struct {
+	Repo     string
+	RepoPath string
+	Account  string
+	Module   string
+	Path     string
+	Version  string
+	Indirect bool
+}
+
+func runDependents(cmd *cobra.Command, args []string) error {
+	module := args[0]
+
+	cyan := color.New(color.FgCyan)
+	green := color.New(color.FgGreen)
+	yellow := color.New(color.FgYellow)
+	gray := color.New(color.FgWhite)
+
+	cfg, err := config.Load()
+	if err != nil {
+		color.Red("✗ Error al cargar configuración: %v", err)
+		return err
+	}
+
+	fmt.Println()
+	cyan.Printf("🔎 Buscando consumidores de %s...\n", module)
+
+	repos, errs := scanRepositories(cfg, scanOptions{
+		Account: dependentsAccount,
+		Owner:   dependentsOwner,
+		Refresh: dependentsRefresh,
+	})
+	gray.Printf("  %d repositorios revisados\n", len(repos))
+
+	dependents := findDependents(repos, module)
+
+	fmt.Println()
+	if len(dependents) == 0 {
+		yellow.Printf("Ningún repositorio requiere %s\n", module)
+	} else {
+		printDependents(dependents, module)
+	}
+
+	if len(errs) > 0 {
+		fmt.Println()
+		yellow.Printf("! %d errores durante la búsqueda:\n", len(errs))
+		for _, err := range errs {
+			gray.Printf("  %v\n", err)
+		}
+	}
+
+	if len(dependents) > 0 {
+		consumers := make(map[string]bool)
+		for _, d := range dependents {
+			consumers[d.Repo] = true
+		}
+		fmt.Println()
+		green.Printf("✔ %d repositorios dependen de %s\n", len(consumers), module)
+	}
+	fmt.Println()
+
+	return nil
+}
+
+// findDependents retorna los repositorios que requieren el módulo en cualquier
+// versión mayor, ordenados por versión (la más reciente primero) y repositorio
+func findDependents(repos []scannedRepo, module string) []dependent {
+	base, _ := gomod.SplitMajorSuffix(module)
+
+	var dependents []dependent
+	for _, r := range repos {
+		if r.GoMod.Module == module {
+			continue
+		}
+		for _, req := range r.GoMod.Require {
+			if reqBase, _ := gomod.SplitMajorSuffix(req.Path); reqBase != base {
+				continue
+			}
+			dependents = append(dependents, dependent{
+				Repo:     r.Repo,
+				RepoPath: r.Library.Path,
+				Account:  r.Account.Name,
+				Module:   r.GoMod.Module,
+				Path:     req.Path,
+				Version:  req.Version,
+				Indirect: req.Indirect,
+			})
+		}
+	}
+
+	sort.Slice(dependents, func(i, j int) bool {
+		if c := gomod.CompareVersions(dependents[i].Version, dependents[j].Version); c != 0 {
+			return c > 0
+		}
+		return dependents[i].Repo < dependents[j].Repo
+	})
+	return dependents
+}
+
+// printDependents muestra los consumidores y un resumen por versión
+func printDependents(dependents []dependent, module string) {
+	blue := color.New(color.FgBlue)
+	gray := color.New(color.FgWhite)
+
+	width := 0
+	for _, d := range dependents {
+		if len(d.Repo) > width {
+			width = len(d.Repo)
+		}
+	}
+
+	count := make(map[string]int)
+	var versions []string
+	for _, d := range dependents {
+		fmt.Printf("  %-*s  ", width, d.Repo)
+		blue.Printf("%-12s", d.Version)
+
+		var notes []string
+		if d.Path != module {
+			notes = append(notes, d.Path)
+		}
+		if d.Indirect {
+			notes = append(notes, "indirecta")
+		}
+		notes = append(notes, "cuenta: "+d.Account)
+		gray.Printf(" %s\n", strings.Join(notes, ", "))
+
+		label := d.Version
+		if d.Path != module {
+			label += " (" + d.Path + ")"
+		}
+		if count[label] == 0 {
+			versions = append(versions, label)
+		}
+		count[label]++
+	}
+
+	fmt.Println()
+	gray.Println("Por versión:")
+	for _, v := range versions {
+		gray.Printf("  %-12s %d\n", v, count[v])
+	}
+}
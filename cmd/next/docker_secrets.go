@@ -0,0 +1,221 @@
+package next
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/reitmas32/next/internal/config"
+	"github.com/spf13/cobra"
+)
+
+var (
+	dockerSecretsFormat string
+	dockerSecretsOutput string
+	dockerSecretsID     string
+)
+
+var dockerSecretsCmd = &cobra.Command{
+	Use:   "docker-secrets",
+	Short: "Genera un secret de BuildKit para compilar con dependencias privadas",
+	Long: `Genera un archivo de secret de BuildKit con las credenciales de las
+dependencias privadas del proyecto (limitado a sus dominios y owners) e
+imprime el fragmento de Dockerfile que lo monta con RUN --mount=type=secret.
+El token nunca queda en las capas de la imagen ni en los argumentos de procesos.
+
+Formatos:
+  gitconfig  reglas url.insteadOf por owner (soporta varias cuentas por dominio)
+  netrc      una entrada por dominio (una sola cuenta por dominio)
+  goauth     script para GOAUTH (Go 1.24+, solo proxy y peticiones go-get=1)
+
+Sin --output el secret se escribe en stdout y el fragmento en stderr.
+
+Ejemplos:
+  next docker-secrets -o .next-secret
+  docker build --secret id=next,src=.next-secret .
+
+  next docker-secrets --format netrc > .netrc.secret`,
+	RunE: runDockerSecrets,
+}
+
+func init() {
+	dockerSecretsCmd.Flags().StringVarP(&dockerSecretsFormat, "format", "f", "gitconfig", "Formato del secret: gitconfig, netrc, goauth")
+	dockerSecretsCmd.Flags().StringVarP(&dockerSecretsOutput, "output", "o", "", "Archivo donde escribir el secret (default: stdout)")
+	dockerSecretsCmd.Flags().StringVar(&dockerSecretsID, "id", "next", "ID del secret de BuildKit")
+
+	rootCmd.AddCommand(dockerSecretsCmd)
+}
+
+// secretScope agrupa un prefijo dominio/owner con la cuenta que lo maneja
+type secretScope struct {
+	Domain  string
+	Owner   string
+	Account *config.Account
+}
+
+func runDockerSecrets(cmd *cobra.Command, args []string) error {
+	yellow := color.New(color.FgYellow)
+
+	if dockerSecretsFormat != "gitconfig" && dockerSecretsFormat != "netrc" && dockerSecretsFormat != "goauth" {
+		return fmt.Errorf("formato inválido: %s (use 'gitconfig', 'netrc' o 'goauth')", dockerSecretsFormat)
+	}
+
+	// Los mensajes van a stderr cuando el secret se escribe en stdout
+	info := io.Writer(os.Stdout)
+	if dockerSecretsOutput == "" {
+		info = os.Stderr
+		colorOutput := color.Output
+		color.Output = os.Stderr
+		defer func() { color.Output = colorOutput }()
+	}
+
+	project, err := loadProject()
+	if err != nil {
+		color.Red("✗ %v", err)
+		return err
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		color.Red("✗ Error al cargar configuración: %v", err)
+		return err
+	}
+
+	privateDeps, goprivatePatterns, _ := resolvePrivateDependencies(cfg, project.Dependencies, "owner")
+	if len(privateDeps) == 0 {
+		yellow.Println("No se detectaron dependencias privadas; no se generó ningún secret")
+		return nil
+	}
+
+	scopes := secretScopes(privateDeps)
+
+	var secret string
+	switch dockerSecretsFormat {
+	case "gitconfig":
+		secret = gitconfigSecret(scopes)
+	case "netrc":
+		var conflicts []string
+		secret, conflicts = netrcSecret(scopes)
+		for _, domain := range conflicts {
+			yellow.Printf("! %s usa varias cuentas; netrc solo admite una por dominio (use --format gitconfig)\n", domain)
+		}
+	case "goauth":
+		secret = goauthSecret(scopes)
+	}
+
+	if dockerSecretsOutput == "" {
+		fmt.Print(secret)
+	} else if err := os.WriteFile(dockerSecretsOutput, []byte(secret), 0600); err != nil {
+		color.Red("✗ Error al escribir secret: %v", err)
+		return err
+	}
+
+	printDockerSnippet(info, strings.Join(goprivatePatterns, ","))
+	return nil
+}
+
+// secretScopes agrupa las dependencias privadas por dominio/owner
+func secretScopes(privateDeps []privateDependency) []secretScope {
+	seen := make(map[string]bool)
+	var scopes []secretScope
+
+	for _, dep := range privateDeps {
+		key := dep.Domain + "/" + dep.Owner
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		scopes = append(scopes, secretScope{Domain: dep.Domain, Owner: dep.Owner, Account: dep.Account})
+	}
+
+	sort.Slice(scopes, func(i, j int) bool {
+		return scopes[i].Domain+"/"+scopes[i].Owner < scopes[j].Domain+"/"+scopes[j].Owner
+	})
+	return scopes
+}
+
+// gitconfigSecret genera reglas insteadOf limitadas a cada owner
+func gitconfigSecret(scopes []secretScope) string {
+	var b strings.Builder
+	for _, s := range scopes {
+		base := fmt.Sprintf("https://%s/%s/", s.Domain, s.Owner)
+		auth := fmt.Sprintf("https://%s:%s@%s/%s/", s.Account.GitUsername(), s.Account.Token, s.Domain, s.Owner)
+		fmt.Fprintf(&b, "[url \"%s\"]\n\tinsteadOf = %s\n", auth, base)
+	}
+	return b.String()
+}
+
+// netrcSecret genera una entrada por dominio; retorna los dominios con más de una cuenta
+func netrcSecret(scopes []secretScope) (string, []string) {
+	accounts := make(map[string]string)
+	var conflicts []string
+	var b strings.Builder
+
+	for _, s := range scopes {
+		name, ok := accounts[s.Domain]
+		if ok {
+			if name != s.Account.Name {
+				conflicts = append(conflicts, s.Domain)
+			}
+			continue
+		}
+		accounts[s.Domain] = s.Account.Name
+		fmt.Fprintf(&b, "machine %s login %s password %s\n", s.Domain, s.Account.GitUsername(), s.Account.Token)
+	}
+
+	return b.String(), conflicts
+}
+
+// goauthSecret genera un script que imprime la respuesta de GOAUTH
+func goauthSecret(scopes []secretScope) string {
+	var credentials []goauthCredential
+	for _, s := range scopes {
+		credentials = append(credentials, goauthCredential{
+			Prefixes: []string{fmt.Sprintf("https://%s/%s", s.Domain, s.Owner)},
+			Account:  s.Account,
+		})
+	}
+
+	var b strings.Builder
+	b.WriteString("#!/bin/sh\ncat <<'EOF'\n")
+	_ = writeGoAuth(&b, credentials)
+	b.WriteString("EOF\n")
+	return b.String()
+}
+
+// printDockerSnippet imprime el fragmento de Dockerfile y el comando de build
+func printDockerSnippet(w io.Writer, goprivate string) {
+	secretPath := dockerSecretsOutput
+	if secretPath == "" {
+		secretPath = "<archivo-del-secret>"
+	}
+
+	var mount, run string
+	switch dockerSecretsFormat {
+	case "gitconfig":
+		mount = fmt.Sprintf("--mount=type=secret,id=%s,target=/root/.gitconfig", dockerSecretsID)
+		run = "go mod download"
+	case "netrc":
+		mount = fmt.Sprintf("--mount=type=secret,id=%s,target=/root/.netrc", dockerSecretsID)
+		run = "go mod download"
+	case "goauth":
+		mount = fmt.Sprintf("--mount=type=secret,id=%s,target=/run/secrets/goauth,mode=0555", dockerSecretsID)
+		run = "GOAUTH=/run/secrets/goauth go mod download"
+	}
+
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "# Dockerfile (la primera línea debe ser: # syntax=docker/dockerfile:1)")
+	fmt.Fprintf(w, "ENV GOPRIVATE=%s\n", goprivate)
+	fmt.Fprintln(w, "COPY go.mod go.sum* ./")
+	fmt.Fprintf(w, "RUN %s \\\n    %s\n", mount, run)
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "# Build")
+	fmt.Fprintf(w, "DOCKER_BUILDKIT=1 docker build --secret id=%s,src=%s .\n", dockerSecretsID, secretPath)
+	if dockerSecretsOutput != "" {
+		fmt.Fprintln(w)
+		fmt.Fprintf(w, "# No agregue %s a git ni al contexto de build (.gitignore / .dockerignore)\n", dockerSecretsOutput)
+	}
+}
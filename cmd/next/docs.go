@@ -0,0 +1,223 @@
+package next
+
+import (
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/reitmas32/next/internal/config"
+	"github.com/reitmas32/next/internal/docs"
+	"github.com/reitmas32/next/internal/gomod"
+	"github.com/spf13/cobra"
+)
+
+var (
+	docsAddr   string
+	docsDeps   bool
+	docsOutput string
+)
+
+var docsCmd = &cobra.Command{
+	Use:   "docs",
+	Short: "Documentación de módulos privados",
+}
+
+var docsServeCmd = &cobra.Command{
+	Use:   "serve <módulo>[@versión]...",
+	Short: "Sirve localmente la documentación de módulos privados",
+	Long: `Descarga los módulos indicados con las cuentas configuradas (sin versión se
+usa la última), extrae la documentación de cada package con go/doc y la
+sirve como un sitio HTML: packages, tipos, funciones, métodos y ejemplos.
+
+También se cargan las dependencias privadas de cada módulo (en la versión que
+requiere su go.mod) para que los links entre módulos privados funcionen. Los
+links a packages públicos apuntan a pkg.go.dev.
+
+Ejemplos:
+  next docs serve github.com/mi-empresa/core-lib
+  next docs serve github.com/mi-empresa/core-lib@v1.3.0 github.com/mi-empresa/auth
+  next docs serve github.com/mi-empresa/core-lib --deps=false --addr localhost:8080`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runDocsServe,
+}
+
+var docsExportCmd = &cobra.Command{
+	Use:   "export <módulo>[@versión]...",
+	Short: "Exporta la documentación de módulos privados como sitio estático",
+	Long: `Genera el mismo sitio que 'next docs serve' como archivos HTML estáticos
+(<salida>/<import path>/index.html). Los links son relativos, así que el
+directorio se puede publicar en cualquier servidor o ruta.
+
+Ejemplos:
+  next docs export github.com/mi-empresa/core-lib --output ./site`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runDocsExport,
+}
+
+func init() {
+	docsServeCmd.Flags().StringVar(&docsAddr, "addr", "localhost:6060", "Dirección donde escuchar")
+	docsExportCmd.Flags().StringVarP(&docsOutput, "output", "o", "docs", "Directorio de salida")
+	for _, c := range []*cobra.Command{docsServeCmd, docsExportCmd} {
+		c.Flags().BoolVar(&docsDeps, "deps", true, "Incluir las dependencias privadas de cada módulo")
+	}
+
+	docsCmd.AddCommand(docsServeCmd)
+	docsCmd.AddCommand(docsExportCmd)
+	rootCmd.AddCommand(docsCmd)
+}
+
+func runDocsServe(cmd *cobra.Command, args []string) error {
+	site, err := loadDocsSite(args)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println()
+	color.New(color.FgCyan).Printf("📚 Documentación en http://%s\n", docsAddr)
+	fmt.Println()
+
+	return http.ListenAndServe(docsAddr, site)
+}
+
+func runDocsExport(cmd *cobra.Command, args []string) error {
+	site, err := loadDocsSite(args)
+	if err != nil {
+		return err
+	}
+
+	dir, err := filepath.Abs(docsOutput)
+	if err != nil {
+		return err
+	}
+
+	pages, err := site.Export(dir)
+	if err != nil {
+		color.Red("✗ Error al exportar: %v", err)
+		return err
+	}
+
+	fmt.Println()
+	color.New(color.FgGreen).Printf("✔ %d páginas exportadas en %s\n", pages, dir)
+	fmt.Println()
+	return nil
+}
+
+// loadDocsSite descarga los módulos (y sus dependencias privadas con --deps)
+// y arma el sitio de documentación
+func loadDocsSite(args []string) (*docs.Site, error) {
+	cyan := color.New(color.FgCyan)
+	gray := color.New(color.FgWhite)
+	yellow := color.New(color.FgYellow)
+
+	cfg, err := config.Load()
+	if err != nil {
+		color.Red("✗ Error al cargar configuración: %v", err)
+		return nil, err
+	}
+
+	type target struct {
+		Module, Version string
+		Dependency      bool
+	}
+
+	var queue []target
+	for _, arg := range args {
+		module, version, _ := strings.Cut(arg, "@")
+		if version == "latest" {
+			version = ""
+		}
+		queue = append(queue, target{Module: module, Version: version})
+	}
+
+	fmt.Println()
+	cyan.Println("📦 Descargando módulos...")
+
+	var modules []*docs.Module
+	loaded := make(map[string]bool)
+
+	for len(queue) > 0 {
+		t := queue[0]
+		queue = queue[1:]
+		if loaded[t.Module] {
+			continue
+		}
+		loaded[t.Module] = true
+
+		m, mod, err := loadModuleDocs(cfg, t.Module, t.Version)
+		if err != nil {
+			// Una dependencia que no se puede cargar solo pierde sus links
+			if t.Dependency {
+				yellow.Printf("  ! %s: %v\n", t.Module, err)
+				continue
+			}
+			color.Red("  ✗ %s: %v", t.Module, err)
+			return nil, err
+		}
+
+		gray.Printf("  ✔ %s@%s (%d packages)\n", m.Path, m.Version, len(m.Packages))
+		modules = append(modules, m)
+
+		if !docsDeps || mod == nil {
+			continue
+		}
+		for _, r := range mod.Require {
+			if loaded[r.Path] {
+				continue
+			}
+			if _, _, err := accountForModule(cfg, r.Path); err == nil {
+				queue = append(queue, target{Module: r.Path, Version: r.Version, Dependency: true})
+			}
+		}
+	}
+
+	// Los packages privados que no están en el sitio no tienen documentación pública
+	external := func(importPath string) string {
+		if _, err := cfg.GetAccountForModule(importPath); err == nil {
+			return ""
+		}
+		return "https://pkg.go.dev/" + importPath
+	}
+
+	return docs.NewSite(modules, external), nil
+}
+
+// loadModuleDocs descarga una versión de un módulo privado (la última si
+// version está vacía) y extrae su documentación
+func loadModuleDocs(cfg *config.Config, module, version string) (*docs.Module, *gomod.File, error) {
+	src, _, err := moduleSource(cfg, module)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := src.Sync(); err != nil {
+		return nil, nil, err
+	}
+
+	if version == "" {
+		versions, err := src.Versions()
+		if err != nil {
+			return nil, nil, err
+		}
+		version = gomod.LatestVersion(versions)
+		if version == "" {
+			return nil, nil, fmt.Errorf("no tiene versiones publicadas")
+		}
+	}
+
+	build, err := src.Build(version)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	m, err := docs.LoadZip(module, version, build.Zip)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	mod, err := gomod.ParseData(build.Mod)
+	if err != nil {
+		return m, nil, nil
+	}
+	return m, mod, nil
+}
@@ -0,0 +1,186 @@
+package next
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/fatih/color"
+	"github.com/reitmas32/next/internal/config"
+	"github.com/spf13/cobra"
+)
+
+var execScope string
+
+var execCmd = &cobra.Command{
+	Use:   "exec <comando> [args...]",
+	Short: "Ejecuta un comando con credenciales efímeras para módulos privados",
+	Long: `Resuelve las cuentas de las dependencias privadas igual que 'next check'
+y ejecuta el comando con GOPRIVATE, GOAUTH (Go 1.24+) y GIT_CONFIG_*
+inyectados en su entorno. No se escribe nada en disco: al terminar el
+comando no queda configuración ni credenciales.
+
+Las señales (Ctrl+C, SIGTERM, SIGHUP) se reenvían al proceso hijo y
+next termina con el mismo código de salida.
+
+Si el directorio actual no tiene go.mod ni go.work, se usan los owners de
+todas las cuentas configuradas.
+
+Los flags de next van antes del comando: todo lo que sigue al comando se
+le pasa sin cambios ('--' es opcional).
+
+Ejemplos:
+  next exec go mod download
+  next exec go build ./...
+  next exec --scope module go get github.com/mi-empresa/core-lib@v1.2.0`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runExec,
+	// El código de salida del comando se reporta con ExitError, sin mensaje
+	SilenceErrors: true,
+	SilenceUsage:  true,
+}
+
+// ExitError indica el código con el que next debe terminar (ej: el código de
+// salida del comando ejecutado por 'next exec'); main llama a os.Exit con él
+type ExitError struct {
+	Code int
+}
+
+func (e *ExitError) Error() string {
+	return fmt.Sprintf("el comando terminó con código %d", e.Code)
+}
+
+func init() {
+	execCmd.Flags().StringVar(&execScope, "scope", "owner", "Granularidad de los patrones de GOPRIVATE: domain, owner, module")
+	// Los flags después del comando son del comando (next exec go build -v ./...)
+	execCmd.Flags().SetInterspersed(false)
+
+	rootCmd.AddCommand(execCmd)
+}
+
+func runExec(cmd *cobra.Command, args []string) error {
+	if execScope != "domain" && execScope != "owner" && execScope != "module" {
+		return fmt.Errorf("scope inválido: %s (use 'domain', 'owner' o 'module')", execScope)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		color.Red("✗ Error al cargar configuración: %v", err)
+		return err
+	}
+
+	env, err := execEnv(cfg, execScope)
+	if err != nil {
+		color.Red("✗ %v", err)
+		return err
+	}
+
+	if code := runWithEnv(args, env); code != 0 {
+		return &ExitError{Code: code}
+	}
+	return nil
+}
+
+// execEnv calcula el entorno con credenciales para el proyecto actual o,
+// si no hay proyecto, para todas las cuentas
+func execEnv(cfg *config.Config, patternScope string) ([]string, error) {
+	var dependencies []string
+	if p, err := loadProject(); err == nil {
+		dependencies = p.Dependencies
+	}
+
+	privateDeps, goprivatePatterns, goinsecurePatterns := resolvePrivateDependencies(cfg, dependencies, patternScope)
+	if len(privateDeps) == 0 {
+		privateDeps, goprivatePatterns, goinsecurePatterns = resolvePrivateDependencies(cfg, accountPrefixes(cfg), patternScope)
+	}
+
+	return credentialEnv(privateDeps, goprivatePatterns, goinsecurePatterns, true)
+}
+
+// accountPrefixes retorna dominio/owner por cada owner de las cuentas
+// (o solo el dominio para cuentas wildcard)
+func accountPrefixes(cfg *config.Config) []string {
+	var prefixes []string
+	for _, acc := range cfg.Accounts {
+		domain := extractDomain(strings.TrimPrefix(strings.TrimPrefix(acc.Domain, "https://"), "http://"))
+		if acc.IsWildcard() {
+			prefixes = append(prefixes, domain)
+			continue
+		}
+		for _, owner := range acc.Owners {
+			prefixes = append(prefixes, domain+"/"+owner)
+		}
+	}
+	return prefixes
+}
+
+// runWithEnv ejecuta el comando con las variables extra, reenvía señales y
+// retorna su código de salida (128+señal si terminó por una señal)
+func runWithEnv(args []string, extraEnv []string) int {
+	child := exec.Command(args[0], args[1:]...)
+	child.Stdin = os.Stdin
+	child.Stdout = os.Stdout
+	child.Stderr = os.Stderr
+	child.Env = mergeEnv(os.Environ(), extraEnv)
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM, syscall.SIGHUP, syscall.SIGQUIT)
+	defer signal.Stop(signals)
+
+	if err := child.Start(); err != nil {
+		color.Red("✗ Error al ejecutar %s: %v", args[0], err)
+		return 127
+	}
+
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		for {
+			select {
+			case sig := <-signals:
+				_ = child.Process.Signal(sig)
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	err := child.Wait()
+	if err == nil {
+		return 0
+	}
+
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		if status, ok := exitErr.Sys().(syscall.WaitStatus); ok && status.Signaled() {
+			return 128 + int(status.Signal())
+		}
+		return exitErr.ExitCode()
+	}
+
+	color.Red("✗ Error al ejecutar %s: %v", args[0], err)
+	return 1
+}
+
+// mergeEnv reemplaza o agrega variables KEY=VALUE sobre el entorno base
+func mergeEnv(base, overrides []string) []string {
+	keys := make(map[string]bool)
+	for _, e := range overrides {
+		key, _, _ := strings.Cut(e, "=")
+		keys[key] = true
+	}
+
+	var env []string
+	for _, e := range base {
+		key, _, _ := strings.Cut(e, "=")
+		if !keys[key] {
+			env = append(env, e)
+		}
+	}
+
+	return append(env, overrides...)
+}
@@ -0,0 +1,151 @@
+package next
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/reitmas32/next/internal/config"
+	"github.com/spf13/cobra"
+)
+
+var goauthCmd = &cobra.Command{
+	Use:   "goauth [url]",
+	Short: "Comando GOAUTH para el toolchain de Go (1.24+)",
+	Long: `Implementa el protocolo de comandos GOAUTH ('go help goauth').
+
+Sin argumentos, retorna un header Authorization por cada cuenta configurada,
+asociado al prefijo https://dominio/owner (o https://dominio para cuentas
+wildcard). Go usa el prefijo más largo que coincide con cada URL.
+
+Con una URL (reintento tras un 4xx), retorna la credencial de la cuenta que
+corresponde a esa URL según GetAccountForModule.
+
+'next check' lo configura automáticamente cuando el toolchain lo soporta:
+  go env -w GOAUTH='netrc;/usr/local/bin/next goauth'`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runGoAuth,
+}
+
+func init() {
+	rootCmd.AddCommand(goauthCmd)
+}
+
+// goauthCredential asocia prefijos de URL con el header Authorization de una cuenta
+type goauthCredential struct {
+	Prefixes []string
+	Account  *config.Account
+}
+
+func runGoAuth(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+
+	var credentials []goauthCredential
+	if len(args) == 0 {
+		credentials = goauthCredentials(cfg)
+	} else if cred := goauthCredentialForURL(cfg, args[0]); cred != nil {
+		credentials = append(credentials, *cred)
+	}
+
+	return writeGoAuth(os.Stdout, credentials)
+}
+
+// goauthCredentials genera un conjunto de credenciales por cuenta.
+// Si dos cuentas comparten prefijo, gana la primera (igual que GetAccountForModule).
+func goauthCredentials(cfg *config.Config) []goauthCredential {
+	var credentials []goauthCredential
+	seen := make(map[string]bool)
+
+	for i := range cfg.Accounts {
+		account := &cfg.Accounts[i]
+		if strings.HasPrefix(account.Domain, "http://") {
+			// GOAUTH solo se aplica a peticiones HTTPS
+			continue
+		}
+
+		domain := extractDomain(strings.TrimPrefix(account.Domain, "https://"))
+
+		var prefixes []string
+		if account.IsWildcard() {
+			prefixes = append(prefixes, "https://"+domain)
+		} else {
+			for _, owner := range account.Owners {
+				prefixes = append(prefixes, fmt.Sprintf("https://%s/%s", domain, owner))
+			}
+		}
+
+		var unique []string
+		for _, p := range prefixes {
+			if !seen[p] {
+				seen[p] = true
+				unique = append(unique, p)
+			}
+		}
+
+		if len(unique) > 0 {
+			credentials = append(credentials, goauthCredential{Prefixes: unique, Account: account})
+		}
+	}
+
+	return credentials
+}
+
+// goauthCredentialForURL retorna la credencial para una URL concreta
+func goauthCredentialForURL(cfg *config.Config, rawURL string) *goauthCredential {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return nil
+	}
+
+	module := u.Host + "/" + strings.Trim(u.Path, "/")
+	account, err := cfg.GetAccountForModule(strings.TrimSuffix(module, "/"))
+	if err != nil {
+		return nil
+	}
+
+	prefix := "https://" + u.Host
+	if owner := extractOwner(module); owner != "" && account.HasOwner(owner) {
+		prefix += "/" + owner
+	}
+
+	return &goauthCredential{Prefixes: []string{prefix}, Account: account}
+}
+
+// writeGoAuth escribe las credenciales en el formato de respuesta de GOAUTH
+func writeGoAuth(w io.Writer, credentials []goauthCredential) error {
+	for _, cred := range credentials {
+		for _, prefix := range cred.Prefixes {
+			if _, err := fmt.Fprintln(w, prefix); err != nil {
+				return err
+			}
+		}
+		if _, err := fmt.Fprintf(w, "\nAuthorization: %s\n\n", basicAuthorization(cred.Account)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// basicAuthorization retorna el valor del header Authorization Basic de una cuenta
+func basicAuthorization(account *config.Account) string {
+	raw := account.GitUsername() + ":" + account.Token
+	return "Basic " + base64.StdEncoding.EncodeToString([]byte(raw))
+}
+
+// goauthCommand retorna el comando GOAUTH que ejecuta este binario
+func goauthCommand() (string, error) {
+	exe, err := os.Executable()
+	if err != nil {
+		return "", err
+	}
+	if strings.ContainsAny(exe, " \t") {
+		exe = "'" + exe + "'"
+	}
+	return exe + " goauth", nil
+}
@@ -0,0 +1,263 @@
+package next
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/reitmas32/next/internal/api"
+	"github.com/reitmas32/next/internal/config"
+	"github.com/reitmas32/next/internal/gomod"
+	"github.com/spf13/cobra"
+)
+
+var infoAccount string
+
+var infoCmd = &cobra.Command{
+	Use:   "info <librería>",
+	Short: "Muestra el detalle de una librería",
+	Long: `Muestra el detalle de una librería: module path (leído del go.mod), última
+versión, rama por defecto, versión de Go, licencia, topics, fecha del último
+commit, el resumen del README y la línea 'go get' lista para copiar.
+
+La librería se puede indicar por module path (la cuenta se elige igual que en
+'next check') o por path del repositorio junto con --account.
+
+Ejemplos:
+  next info github.com/mi-empresa/core-lib
+  next info gitlab.company.com/grupo/subgrupo/lib/v2
+  next info reitmas32/mathutils --account personal`,
+	Args: cobra.ExactArgs(1),
+	RunE: runInfo,
+}
+
+func init() {
+	infoCmd.Flags().StringVarP(&infoAccount, "account", "a", "", "Cuenta a usar (el argumento es el path del repositorio)")
+
+	rootCmd.AddCommand(infoCmd)
+}
+
+// readmeSummaryLength limita el resumen del README
+const readmeSummaryLength = 400
+
+func runInfo(cmd *cobra.Command, args []string) error {
+	cyan := color.New(color.FgCyan)
+	green := color.New(color.FgGreen)
+	yellow := color.New(color.FgYellow)
+	gray := color.New(color.FgWhite)
+
+	cfg, err := config.Load()
+	if err != nil {
+		color.Red("✗ Error al cargar configuración: %v", err)
+		return err
+	}
+
+	repo, err := infoRepository(cfg, args[0])
+	if err != nil {
+		color.Red("✗ %v", err)
+		return err
+	}
+
+	provider, err := api.NewProvider(repo.Account.Provider, repo.Account.Domain, repo.Account.Token)
+	if err != nil {
+		color.Red("✗ Error al crear cliente: %v", err)
+		return err
+	}
+
+	meta, err := provider.GetRepository(repo.RepoPath())
+	if errors.Is(err, api.ErrNotFound) {
+		color.Red("✗ No se encontró el repositorio %s o la cuenta %s no tiene acceso", repo.RepoPath(), repo.Account.Name)
+		return err
+	}
+	if err != nil {
+		color.Red("✗ Error al obtener el repositorio: %v", err)
+		return err
+	}
+
+	// El module path real es el del go.mod de la rama por defecto
+	f, err := moduleGoMod(provider, repo, "")
+	if errors.Is(err, api.ErrNotFound) {
+		color.Red("✗ %s no tiene go.mod en la rama por defecto", repo.RepoPath())
+		return err
+	}
+	if err != nil {
+		color.Red("✗ Error al leer go.mod: %v", err)
+		return err
+	}
+	repo.Module = f.Module
+
+	// Solo se consulta la fecha de la última versión: ListVersions haría
+	// una consulta por tag
+	tags, err := provider.ListTags(repo.RepoPath())
+	if err != nil {
+		color.Red("✗ Error al obtener versiones: %v", err)
+		return err
+	}
+
+	latest := gomod.LatestVersion(repo.ModuleVersions(repo.TagVersions(tags)))
+	latestDate := ""
+	if latest != "" {
+		latestDate = provider.GetCommitDate(repo.RepoPath(), repo.Tag(latest))
+	}
+
+	fmt.Println()
+	cyan.Printf("%s", meta.Name)
+	if meta.Visibility == "public" {
+		green.Printf(" [público]")
+	} else {
+		yellow.Printf(" [privado]")
+	}
+	fmt.Println()
+	if meta.Description != "" {
+		gray.Printf("  %s\n", meta.Description)
+	}
+	fmt.Println()
+
+	field := func(label, value string) {
+		if value == "" {
+			value = "-"
+		}
+		gray.Printf("  %-15s", label)
+		fmt.Println(value)
+	}
+
+	field("módulo", f.Module)
+	if latest != "" {
+		label := latest
+		if latestDate != "" {
+			label += " (" + latestDate + ")"
+		}
+		field("última versión", label)
+	} else {
+		field("última versión", "sin versiones publicadas")
+	}
+	field("rama", meta.DefaultBranch)
+	field("go", f.Go)
+	field("licencia", meta.License)
+	field("topics", strings.Join(meta.Topics, ", "))
+	field("último commit", meta.LastCommit)
+	field("url", meta.URL)
+	field("cuenta", repo.Account.Name)
+
+	if summary := readmeSummary(provider, repo); summary != "" {
+		fmt.Println()
+		for _, line := range wrapText(summary, 76) {
+			fmt.Printf("  %s\n", line)
+		}
+	}
+
+	version := latest
+	if version == "" {
+		version = "latest"
+	}
+	fmt.Println()
+	green.Printf("  go get %s@%s\n", f.Module, version)
+	if meta.Visibility != "public" {
+		gray.Println("  (configure las credenciales antes con: next check)")
+	}
+	fmt.Println()
+
+	return nil
+}
+
+// infoRepository ubica el repositorio de la librería: por module path, o por
+// path del repositorio cuando se indica la cuenta (o es un path sin dominio)
+func infoRepository(cfg *config.Config, library string) (*moduleRepo, error) {
+	first, _, _ := strings.Cut(library, "/")
+	if infoAccount == "" && strings.Contains(first, ".") {
+		return moduleRepository(cfg, library)
+	}
+
+	account, err := cfg.GetAccount(infoAccount)
+	if err != nil {
+		return nil, err
+	}
+
+	domain := normalizeAccountDomain(account.Domain)
+	scheme, env := accountGitEnv(account, domain)
+	return &moduleRepo{
+		Account: account,
+		Repo:    domain + "/" + strings.Trim(library, "/"),
+		Scheme:  scheme,
+		Env:     env,
+	}, nil
+}
+
+// readmeSummary retorna el primer párrafo de texto del README del módulo (o
+// de la raíz del repositorio)
+func readmeSummary(provider api.Provider, repo *moduleRepo) string {
+	dirs := []string{""}
+	if repo.Subdir != "" {
+		dirs = []string{repo.Subdir, ""}
+	}
+
+	for _, dir := range dirs {
+		for _, name := range []string{"README.md", "README", "readme.md", "README.rst", "README.txt"} {
+			data, err := provider.GetFile(repo.RepoPath(), path.Join(dir, name), "")
+			if err != nil {
+				continue
+			}
+			return firstParagraph(string(data), readmeSummaryLength)
+		}
+	}
+	return ""
+}
+
+// firstParagraph extrae el primer párrafo de texto de un README, saltando
+// títulos, badges, HTML y bloques de código
+func firstParagraph(text string, limit int) string {
+	var paragraph []string
+	inCode := false
+
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "```") {
+			inCode = !inCode
+			continue
+		}
+
+		skip := inCode ||
+			strings.HasPrefix(line, "#") ||
+			strings.HasPrefix(line, "[![") ||
+			strings.HasPrefix(line, "![") ||
+			strings.HasPrefix(line, "<") ||
+			strings.HasPrefix(line, "|") ||
+			strings.Trim(line, "=-*_") == ""
+
+		if skip {
+			if len(paragraph) > 0 {
+				break
+			}
+			continue
+		}
+		paragraph = append(paragraph, line)
+	}
+
+	summary := []rune(strings.Join(paragraph, " "))
+	if len(summary) > limit {
+		return strings.TrimSpace(string(summary[:limit])) + "…"
+	}
+	return string(summary)
+}
+
+// wrapText divide un texto en líneas de hasta width caracteres
+func wrapText(text string, width int) []string {
+	var lines []string
+	line := ""
+	for _, word := range strings.Fields(text) {
+		if line != "" && len([]rune(line))+1+len([]rune(word)) > width {
+			lines = append(lines, line)
+			line = ""
+		}
+		if line != "" {
+			line += " "
+		}
+		line += word
+	}
+	if line != "" {
+		lines = append(lines, line)
+	}
+	return lines
+}
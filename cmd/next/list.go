@@ -2,6 +2,7 @@ package next
 
 import (
 	"fmt"
+	"sort"
 
 	"github.com/fatih/color"
 	"github.com/reitmas32/next/internal/api"
@@ -10,9 +11,10 @@ import (
 )
 
 var (
-	listAccount    string
+	listAccounts   accountSelection
 	listVisibility string
 	listOwner      string
+	listCache      apiCacheOptions
 )
 
 var listCmd = &cobra.Command{
@@ -26,14 +28,27 @@ Soporta tanto repositorios públicos como privados.
 Ejemplo:
   next list --account gitlab-main
   next list --visibility public
-  next list --owner myorg --visibility private`,
+  next list --owner myorg --visibility private
+  next list --offline
+  next list --all-accounts
+  next list --domain gitlab.com
+
+Las respuestas de la API se guardan en ~/.next/cache/api por cuenta y
+consulta. Mientras estén vigentes (cache_ttl en la configuración, 10m por
+defecto) no se consulta la red; después se revalidan con ETag. Use
+--refresh para ignorar el cache y --offline para usar solo el cache.
+
+Con --all-accounts (o --domain) se consultan las cuentas en paralelo y las
+librerías se muestran una vez por módulo, con las cuentas que las ven. Si una
+cuenta falla se informa y se muestran los resultados de las demás.`,
 	RunE: runList,
 }
 
 func init() {
-	listCmd.Flags().StringVarP(&listAccount, "account", "a", "", "Nombre de la cuenta a usar")
+	addAccountSelectionFlags(listCmd, &listAccounts)
 	listCmd.Flags().StringVarP(&listVisibility, "visibility", "v", "all", "Filtrar por visibilidad: all, public, private")
 	listCmd.Flags().StringVarP(&listOwner, "owner", "o", "", "Filtrar por usuario/organización específico")
+	addAPICacheFlags(listCmd, &listCache)
 }
 
 func runList(cmd *cobra.Command, args []string) error {
@@ -44,20 +59,6 @@ func runList(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	// Obtener cuenta
-	account, err := cfg.GetAccount(listAccount)
-	if err != nil {
-		color.Red("✗ %v", err)
-		return err
-	}
-
-	// Crear cliente del proveedor
-	provider, err := api.NewProvider(account.Provider, account.Domain, account.Token)
-	if err != nil {
-		color.Red("✗ Error al crear cliente: %v", err)
-		return err
-	}
-
 	// Configurar opciones de listado
 	opts := api.ListOptions{
 		Owner: listOwner,
@@ -72,6 +73,24 @@ func runList(cmd *cobra.Command, args []string) error {
 		opts.Visibility = api.VisibilityAll
 	}
 
+	if listAccounts.multiple() {
+		return runListAllAccounts(cfg, opts)
+	}
+
+	// Obtener cuenta
+	account, err := cfg.GetAccount(listAccounts.Account)
+	if err != nil {
+		color.Red("✗ %v", err)
+		return err
+	}
+
+	// Crear cliente del proveedor
+	provider, err := newCachedProvider(cfg, account, listCache)
+	if err != nil {
+		color.Red("✗ Error al crear cliente: %v", err)
+		return err
+	}
+
 	// Obtener librerías Go
 	libraries, err := provider.ListGoLibrariesWithOptions(opts)
 	if err != nil {
@@ -118,3 +137,88 @@ func runList(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+// listedLibrary es una librería del listado de varias cuentas
+type listedLibrary struct {
+	Module   string
+	Library  api.Library
+	Accounts []*config.Account
+}
+
+// runListAllAccounts lista las librerías de varias cuentas en paralelo y las
+// agrupa por módulo (dominio + path del repositorio)
+func runListAllAccounts(cfg *config.Config, opts api.ListOptions) error {
+	accounts, err := listAccounts.accounts(cfg)
+	if err != nil {
+		color.Red("✗ %v", err)
+		return err
+	}
+
+	results := make([][]api.Library, len(accounts))
+	errs := forEachAccount(accounts, func(i int, acc *config.Account) error {
+		// Las cuentas con owners configurados solo ven esos owners
+		if opts.Owner != "" && !acc.IsWildcard() && !acc.HasOwner(opts.Owner) {
+			return nil
+		}
+
+		provider, err := newCachedProvider(cfg, acc, listCache)
+		if err != nil {
+			return err
+		}
+		results[i], err = provider.ListGoLibrariesWithOptions(opts)
+		return err
+	})
+
+	byModule := make(map[string]*listedLibrary)
+	var libraries []*listedLibrary
+	for i, acc := range accounts {
+		domain := normalizeAccountDomain(acc.Domain)
+		for _, lib := range results[i] {
+			module := domain + "/" + lib.Path
+			l := byModule[module]
+			if l == nil {
+				l = &listedLibrary{Module: module, Library: lib}
+				byModule[module] = l
+				libraries = append(libraries, l)
+			}
+			l.Accounts = append(l.Accounts, acc)
+		}
+	}
+	sort.Slice(libraries, func(i, j int) bool {
+		return libraries[i].Module < libraries[j].Module
+	})
+
+	cyan := color.New(color.FgCyan)
+	magenta := color.New(color.FgMagenta)
+	gray := color.New(color.FgWhite)
+	green := color.New(color.FgGreen)
+	yellow := color.New(color.FgYellow)
+
+	fmt.Println()
+	for _, l := range libraries {
+		cyan.Printf("%-45s", l.Module)
+		if l.Library.Visibility == "public" {
+			green.Printf(" [público]")
+		} else {
+			yellow.Printf(" [privado]")
+		}
+		fmt.Println()
+
+		if l.Library.Description != "" {
+			gray.Printf("  %s\n", l.Library.Description)
+		}
+		magenta.Printf("  cuentas: %s\n", sortedAccountNames(l.Accounts))
+	}
+
+	if len(libraries) == 0 {
+		color.Yellow("No se encontraron librerías Go en las cuentas consultadas")
+	}
+
+	fmt.Println()
+	gray.Printf("librerías: %d, cuentas consultadas: %d\n", len(libraries), len(accounts))
+	if listVisibility != "all" {
+		gray.Printf("filtro: %s\n", listVisibility)
+	}
+
+	return printAccountFailures(accounts, errs)
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/fatih/color"
 	"github.com/reitmas32/next/internal/config"
+	"github.com/reitmas32/next/internal/credentials"
 	"github.com/spf13/cobra"
 )
 
@@ -21,6 +22,11 @@ var logoutCmd = &cobra.Command{
 	Short: "Elimina una cuenta registrada",
 	Long: `Elimina una cuenta del archivo de configuración.
 
+También elimina las credenciales que 'next check' escribió para la cuenta
+(git config y .netrc registradas en ~/.next/credentials.json) cuando ninguna
+otra cuenta las usa, y cualquier copia del token en .netrc o en reglas
+url.insteadOf de versiones anteriores.
+
 Ejemplos:
   # Eliminar cuenta específica
   next logout personal
@@ -72,6 +78,7 @@ func runLogout(cmd *cobra.Command, args []string) error {
 		}
 
 		count := len(cfg.Accounts)
+		removedAccounts := cfg.Accounts
 		cfg.Accounts = []config.Account{}
 
 		if err := cfg.Save(); err != nil {
@@ -80,6 +87,9 @@ func runLogout(cmd *cobra.Command, args []string) error {
 		}
 
 		green.Printf("✔ %d cuenta(s) eliminada(s)\n", count)
+		for _, acc := range removedAccounts {
+			cleanupAccountCredentials(acc)
+		}
 		return nil
 	}
 
@@ -153,6 +163,7 @@ func runLogout(cmd *cobra.Command, args []string) error {
 	}
 
 	green.Printf("✔ Cuenta '%s' eliminada correctamente\n", accountName)
+	cleanupAccountCredentials(*accountToDelete)
 
 	// Mostrar cuentas restantes
 	if len(cfg.Accounts) > 0 {
@@ -169,6 +180,38 @@ func runLogout(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// cleanupAccountCredentials elimina las credenciales escritas en disco para una
+// cuenta eliminada. Los errores se muestran como advertencias.
+func cleanupAccountCredentials(account config.Account) {
+	gray := color.New(color.FgWhite)
+
+	manifest, err := credentials.LoadManifest()
+	if err != nil {
+		color.Yellow("! No se pudo leer el manifiesto de credenciales: %v", err)
+		return
+	}
+
+	removed, err := manifest.ReleaseAccount(account.Name)
+	if err != nil {
+		color.Yellow("! Error al eliminar credenciales de '%s': %v", account.Name, err)
+	}
+	if err := manifest.Save(); err != nil {
+		color.Yellow("! No se pudo guardar el manifiesto de credenciales: %v", err)
+	}
+
+	for _, a := range removed {
+		gray.Printf("  eliminado %s\n", describeArtifact(a))
+	}
+
+	copies, err := credentials.RemoveTokenCopies(account.Token)
+	if err != nil {
+		color.Yellow("! Error al eliminar copias del token de '%s': %v", account.Name, err)
+	}
+	if copies > 0 {
+		gray.Printf("  eliminadas %d copia(s) del token de '%s' en .netrc/.gitconfig\n", copies, account.Name)
+	}
+}
+
 // confirmAction lee la entrada del usuario y retorna true si confirma
 func confirmAction() bool {
 	reader := bufio.NewReader(os.Stdin)
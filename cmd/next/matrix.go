@@ -0,0 +1,595 @@
+package next
+
+import (
+	"encoding/csv"
+	"fmt"
+	"html/template"
+	"io"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/reitmas32/next/internal/config"
+	"github.com/reitmas32/next/internal/gomod"
+	"github.com/spf13/cobra"
+)
+
+var (
+	matrixOwner     string
+	matrixAccount   string
+	matrixLibraries []string
+	matrixFormat    string
+	matrixOutput    string
+	matrixRefresh   bool
+)
+
+var matrixCmd = &cobra.Command{
+	Use:   "matrix",
+	Short: "Muestra qué versión de cada librería interna usa cada servicio",
+	Long: `Revisa todos los repositorios Go que pueden ver las cuentas configuradas
+(igual que 'next dependents') y arma una matriz servicio × librería con la
+versión requerida en el go.mod de la rama por defecto.
+
+Las librerías son los módulos de esos mismos repositorios que otro repositorio
+requiere. Para cada una se consulta la última versión publicada (tags del
+repositorio) y se marcan:
+  - Las celdas con una versión anterior a la última de su misma versión mayor
+    (un servicio en v1 se compara con la última v1, no con v2)
+  - Las librerías con skew: servicios que usan versiones distintas
+
+Formatos:
+  table  Tabla en la terminal (default; sin colores con --output)
+  md     Tabla Markdown (para wikis)
+  csv    Valores separados por comas
+  html   Página HTML independiente
+
+Ejemplos:
+  next matrix
+  next matrix --owner mi-empresa --library github.com/mi-empresa/core-lib
+  next matrix --format md --output matriz.md
+  next matrix --format html --output matriz.html`,
+	RunE: runMatrix,
+}
+
+func init() {
+	matrixCmd.Flags().StringVarP(&matrixOwner, "owner", "o", "", "Solo repositorios de este usuario u organización")
+	matrixCmd.Flags().StringVarP(&matrixAccount, "account", "a", "", "Solo repositorios visibles por esta cuenta")
+	matrixCmd.Flags().StringSliceVarP(&matrixLibraries, "library", "l", nil, "Solo estas librerías (module path, se puede repetir)")
+	matrixCmd.Flags().StringVarP(&matrixFormat, "format", "f", "table", "Formato de salida: table, md, csv, html")
+	matrixCmd.Flags().StringVar(&matrixOutput, "output", "", "Archivo de salida (default: salida estándar)")
+	matrixCmd.Flags().BoolVar(&matrixRefresh, "refresh", false, "Ignorar el cache y consultar de nuevo")
+
+	rootCmd.AddCommand(matrixCmd)
+}
+
+// versionMatrix es el cruce servicio × librería → versión requerida
+type versionMatrix struct {
+	Libraries []*matrixLibrary
+	Services  []*matrixService
+}
+
+// matrixLibrary es una librería interna con al menos un consumidor
+type matrixLibrary struct {
+	// Module es el module path del go.mod de la rama por defecto
+	Module string
+	// Base es el module path sin sufijo /vN; agrupa todas las versiones mayores
+	Base    string
+	Account *config.Account
+	// RepoPath es el path del repositorio en el proveedor (ej: org/lib)
+	RepoPath string
+	// Latest es la última versión publicada del module path de Module (vacía
+	// si no se pudo consultar)
+	Latest string
+	// LatestByMajor es la última versión publicada de cada versión mayor
+	// (v0 y v1 comparten el module path sin sufijo, ver majorKey)
+	LatestByMajor map[int]string
+	// Used son las versiones distintas que requieren los servicios
+	Used  []string
+	Error string
+}
+
+// matrixService es un repositorio que requiere al menos una librería
+type matrixService struct {
+	Repo   string
+	Module string
+	// Versions mapea Base de la librería → versión requerida
+	Versions map[string]string
+}
+
+// Skewed indica si los servicios usan versiones distintas de la librería
+func (l *matrixLibrary) Skewed() bool {
+	return len(l.Used) > 1
+}
+
+// Behind indica si una versión requerida es anterior a la última publicada
+// de su misma versión mayor
+func (l *matrixLibrary) Behind(version string) bool {
+	latest := l.LatestByMajor[majorKey(version)]
+	return latest != "" && gomod.CompareVersions(version, latest) < 0
+}
+
+// majorKey agrupa las versiones por module path: v0 y v1 no llevan sufijo
+// /vN, así que comparten la misma clave
+func majorKey(version string) int {
+	return max(gomod.Major(version), 1)
+}
+
+// Name retorna un nombre corto para encabezados (último elemento del path)
+func (l *matrixLibrary) Name() string {
+	return path.Base(l.Base)
+}
+
+func runMatrix(cmd *cobra.Command, args []string) error {
+	switch matrixFormat {
+	case "table", "md", "csv", "html":
+	default:
+		color.Red("✗ Formato no soportado: %s (use table, md, csv o html)", matrixFormat)
+		return fmt.Errorf("formato no soportado: %s", matrixFormat)
+	}
+
+	// El progreso no debe mezclarse con un reporte que se escribe a la salida estándar
+	progress := io.Writer(os.Stdout)
+	if matrixFormat != "table" && matrixOutput == "" {
+		progress = os.Stderr
+	}
+	gray := color.New(color.FgWhite)
+	yellow := color.New(color.FgYellow)
+
+	cfg, err := config.Load()
+	if err != nil {
+		color.Red("✗ Error al cargar configuración: %v", err)
+		return err
+	}
+
+	fmt.Fprintln(progress)
+	color.New(color.FgCyan).Fprintln(progress, "🔎 Revisando repositorios...")
+
+	repos, errs := scanRepositories(cfg, scanOptions{
+		Account: matrixAccount,
+		Owner:   matrixOwner,
+		Refresh: matrixRefresh,
+	})
+	gray.Fprintf(progress, "  %d repositorios revisados\n", len(repos))
+
+	matrix := buildMatrix(repos, matrixLibraries)
+	if len(matrix.Libraries) == 0 {
+		fmt.Fprintln(progress)
+		yellow.Fprintln(progress, "Ningún repositorio requiere librerías de otro repositorio revisado")
+		fmt.Fprintln(progress)
+		return nil
+	}
+
+	resolveLatestVersions(matrix)
+
+	out := io.Writer(os.Stdout)
+	if matrixOutput != "" {
+		file, err := os.Create(matrixOutput)
+		if err != nil {
+			color.Red("✗ Error al crear %s: %v", matrixOutput, err)
+			return err
+		}
+		defer file.Close()
+		out = file
+	}
+
+	switch matrixFormat {
+	case "md":
+		err = writeMatrixMarkdown(out, matrix)
+	case "csv":
+		err = writeMatrixCSV(out, matrix)
+	case "html":
+		err = writeMatrixHTML(out, matrix)
+	default:
+		printMatrixTable(out, matrix)
+	}
+	if err != nil {
+		color.Red("✗ Error al escribir el reporte: %v", err)
+		return err
+	}
+
+	for _, lib := range matrix.Libraries {
+		if lib.Error != "" {
+			errs = append(errs, fmt.Errorf("%s: %s", lib.Module, lib.Error))
+		}
+	}
+	if len(errs) > 0 {
+		fmt.Fprintln(progress)
+		yellow.Fprintf(progress, "! %d errores durante la búsqueda:\n", len(errs))
+		for _, err := range errs {
+			gray.Fprintf(progress, "  %v\n", err)
+		}
+	}
+
+	if matrixOutput != "" {
+		fmt.Fprintln(progress)
+		color.New(color.FgGreen).Fprintf(progress, "✔ Reporte guardado en %s\n", matrixOutput)
+	}
+	fmt.Fprintln(progress)
+
+	return nil
+}
+
+// buildMatrix cruza los go.mod revisados: las librerías son los módulos de esos
+// repositorios que otro repositorio requiere (en cualquier versión mayor)
+func buildMatrix(repos []scannedRepo, only []string) *versionMatrix {
+	wanted := make(map[string]bool)
+	for _, module := range only {
+		base, _ := gomod.SplitMajorSuffix(module)
+		wanted[base] = true
+	}
+
+	libraries := make(map[string]*matrixLibrary)
+	for _, r := range repos {
+		base, _ := gomod.SplitMajorSuffix(r.GoMod.Module)
+		if r.GoMod.Module == "" || libraries[base] != nil || (len(wanted) > 0 && !wanted[base]) {
+			continue
+		}
+		libraries[base] = &matrixLibrary{
+			Module:   r.GoMod.Module,
+			Base:     base,
+			Account:  r.Account,
+			RepoPath: r.Library.Path,
+		}
+	}
+
+	matrix := &versionMatrix{}
+	used := make(map[string]map[string]bool)
+	for _, r := range repos {
+		own, _ := gomod.SplitMajorSuffix(r.GoMod.Module)
+		service := &matrixService{Repo: r.Repo, Module: r.GoMod.Module, Versions: make(map[string]string)}
+
+		for _, req := range r.GoMod.Require {
+			base, _ := gomod.SplitMajorSuffix(req.Path)
+			if libraries[base] == nil || base == own {
+				continue
+			}
+			// Si requiere varias versiones mayores se muestra la más alta
+			if current, ok := service.Versions[base]; ok && gomod.CompareVersions(current, req.Version) >= 0 {
+				continue
+			}
+			service.Versions[base] = req.Version
+		}
+
+		if len(service.Versions) == 0 {
+			continue
+		}
+		for base, version := range service.Versions {
+			if used[base] == nil {
+				used[base] = make(map[string]bool)
+			}
+			used[base][version] = true
+		}
+		matrix.Services = append(matrix.Services, service)
+	}
+
+	for base, lib := range libraries {
+		if len(used[base]) == 0 {
+			continue
+		}
+		for version := range used[base] {
+			lib.Used = append(lib.Used, version)
+		}
+		sort.Slice(lib.Used, func(i, j int) bool {
+			return gomod.CompareVersions(lib.Used[i], lib.Used[j]) > 0
+		})
+		matrix.Libraries = append(matrix.Libraries, lib)
+	}
+
+	sort.Slice(matrix.Libraries, func(i, j int) bool {
+		return matrix.Libraries[i].Base < matrix.Libraries[j].Base
+	})
+	sort.Slice(matrix.Services, func(i, j int) bool {
+		return matrix.Services[i].Repo < matrix.Services[j].Repo
+	})
+	return matrix
+}
+
+// resolveLatestVersions consulta los tags del repositorio de cada librería y
+// guarda la última versión publicada de cada versión mayor
+func resolveLatestVersions(matrix *versionMatrix) {
+	providers := newProviderPool()
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, scanWorkers)
+	for _, lib := range matrix.Libraries {
+		wg.Add(1)
+		go func(lib *matrixLibrary) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			provider, err := providers.get(lib.Account)
+			if err != nil {
+				lib.Error = err.Error()
+				return
+			}
+
+			tags, err := provider.ListTags(lib.RepoPath)
+			if err != nil {
+				lib.Error = err.Error()
+				return
+			}
+
+			byMajor := make(map[int][]string)
+			for _, v := range (&moduleRepo{Module: lib.Module}).TagVersions(tags) {
+				byMajor[majorKey(v)] = append(byMajor[majorKey(v)], v)
+			}
+
+			lib.LatestByMajor = make(map[int]string, len(byMajor))
+			for key, versions := range byMajor {
+				lib.LatestByMajor[key] = gomod.LatestVersion(versions)
+			}
+
+			_, major := gomod.SplitMajorSuffix(lib.Module)
+			lib.Latest = lib.LatestByMajor[max(major, 1)]
+		}(lib)
+	}
+	wg.Wait()
+}
+
+// printMatrixTable escribe la matriz como tabla: verde la última versión,
+// amarillo las anteriores y * en las librerías con skew. Los colores solo se
+// usan en la terminal.
+func printMatrixTable(w io.Writer, matrix *versionMatrix) {
+	green := color.New(color.FgGreen)
+	yellow := color.New(color.FgYellow)
+	cyan := color.New(color.FgCyan)
+	gray := color.New(color.FgWhite)
+	if w != io.Writer(os.Stdout) {
+		for _, c := range []*color.Color{green, yellow, cyan, gray} {
+			c.DisableColor()
+		}
+	}
+
+	headers := make([]string, len(matrix.Libraries))
+	widths := make([]int, len(matrix.Libraries))
+	for i, lib := range matrix.Libraries {
+		headers[i] = lib.Name()
+		if lib.Skewed() {
+			headers[i] += "*"
+		}
+		widths[i] = max(len(headers[i]), len(lib.Latest), 1)
+		for _, s := range matrix.Services {
+			widths[i] = max(widths[i], len(s.Versions[lib.Base]))
+		}
+	}
+
+	serviceWidth := len("SERVICIO")
+	for _, s := range matrix.Services {
+		serviceWidth = max(serviceWidth, len(s.Repo))
+	}
+
+	fmt.Fprintln(w)
+	gray.Fprintf(w, "%-*s", serviceWidth, "SERVICIO")
+	for i, h := range headers {
+		gray.Fprintf(w, "  %-*s", widths[i], h)
+	}
+	fmt.Fprintln(w)
+
+	cyan.Fprintf(w, "%-*s", serviceWidth, "(última)")
+	for i, lib := range matrix.Libraries {
+		latest := lib.Latest
+		if latest == "" {
+			latest = "?"
+		}
+		cyan.Fprintf(w, "  %-*s", widths[i], latest)
+	}
+	fmt.Fprintln(w)
+
+	behind := 0
+	for _, s := range matrix.Services {
+		fmt.Fprintf(w, "%-*s", serviceWidth, s.Repo)
+		for i, lib := range matrix.Libraries {
+			version, ok := s.Versions[lib.Base]
+			switch {
+			case !ok:
+				gray.Fprintf(w, "  %-*s", widths[i], "-")
+			case lib.Behind(version):
+				yellow.Fprintf(w, "  %-*s", widths[i], version)
+				behind++
+			default:
+				green.Fprintf(w, "  %-*s", widths[i], version)
+			}
+		}
+		fmt.Fprintln(w)
+	}
+
+	fmt.Fprintln(w)
+	skewed := 0
+	for _, lib := range matrix.Libraries {
+		gray.Fprintf(w, "  %-*s %s", len(lib.Name())+1, lib.Name()+":", lib.Module)
+		if lib.Skewed() {
+			yellow.Fprintf(w, "  skew: %s", strings.Join(lib.Used, ", "))
+			skewed++
+		}
+		fmt.Fprintln(w)
+	}
+
+	fmt.Fprintln(w)
+	summary := fmt.Sprintf("%d servicios, %d librerías, %d con skew, %d versiones desactualizadas",
+		len(matrix.Services), len(matrix.Libraries), skewed, behind)
+	if skewed > 0 || behind > 0 {
+		yellow.Fprintf(w, "! %s\n", summary)
+	} else {
+		green.Fprintf(w, "✔ %s\n", summary)
+	}
+}
+
+// writeMatrixMarkdown escribe la matriz como tabla Markdown; las versiones
+// anteriores a la última y las librerías con skew se marcan con ⚠️
+func writeMatrixMarkdown(w io.Writer, matrix *versionMatrix) error {
+	escape := func(s string) string {
+		return strings.ReplaceAll(s, "|", `\|`)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Matriz de versiones\n\nGenerado el %s con `next matrix`.\n\n", time.Now().Format("2006-01-02 15:04"))
+
+	b.WriteString("| Servicio |")
+	for _, lib := range matrix.Libraries {
+		fmt.Fprintf(&b, " `%s` |", escape(lib.Module))
+	}
+	b.WriteString("\n|---|")
+	for range matrix.Libraries {
+		b.WriteString("---|")
+	}
+	b.WriteString("\n| **Última** |")
+	for _, lib := range matrix.Libraries {
+		latest := lib.Latest
+		if latest == "" {
+			latest = "?"
+		}
+		fmt.Fprintf(&b, " **%s** |", latest)
+	}
+	b.WriteString("\n")
+
+	for _, s := range matrix.Services {
+		fmt.Fprintf(&b, "| %s |", escape(s.Repo))
+		for _, lib := range matrix.Libraries {
+			version, ok := s.Versions[lib.Base]
+			switch {
+			case !ok:
+				b.WriteString(" |")
+			case lib.Behind(version):
+				fmt.Fprintf(&b, " %s ⚠️ |", version)
+			default:
+				fmt.Fprintf(&b, " %s |", version)
+			}
+		}
+		b.WriteString("\n")
+	}
+
+	var skewed []string
+	for _, lib := range matrix.Libraries {
+		if lib.Skewed() {
+			skewed = append(skewed, fmt.Sprintf("- `%s`: %s", lib.Module, strings.Join(lib.Used, ", ")))
+		}
+	}
+	if len(skewed) > 0 {
+		b.WriteString("\n## Librerías con skew\n\n")
+		b.WriteString(strings.Join(skewed, "\n") + "\n")
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// writeMatrixCSV escribe la matriz como CSV: una columna por librería y una
+// primera fila con la última versión publicada
+func writeMatrixCSV(w io.Writer, matrix *versionMatrix) error {
+	cw := csv.NewWriter(w)
+
+	header := []string{"service"}
+	latest := []string{"(latest)"}
+	for _, lib := range matrix.Libraries {
+		header = append(header, lib.Module)
+		latest = append(latest, lib.Latest)
+	}
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+	if err := cw.Write(latest); err != nil {
+		return err
+	}
+
+	for _, s := range matrix.Services {
+		row := []string{s.Repo}
+		for _, lib := range matrix.Libraries {
+			row = append(row, s.Versions[lib.Base])
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// matrixHTMLTemplate es una página independiente (sin recursos externos) para
+// publicar en una wiki
+var matrixHTMLTemplate = template.Must(template.New("matrix").Parse(`<!DOCTYPE html>
+<html lang="es">
+<head>
+<meta charset="utf-8">
+<title>Matriz de versiones</title>
+<style>
+body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; margin: 2rem; color: #1f2328; }
+table { border-collapse: collapse; font-size: 0.9rem; }
+th, td { border: 1px solid #d0d7de; padding: 0.35rem 0.6rem; text-align: left; white-space: nowrap; }
+th { background: #f6f8fa; }
+th.skew { background: #fff1c2; }
+tr.latest td { font-weight: bold; background: #f6f8fa; }
+td.current { color: #1a7f37; }
+td.behind { color: #9a6700; background: #fff8c5; }
+.legend { margin-top: 1rem; color: #59636e; font-size: 0.85rem; }
+</style>
+</head>
+<body>
+<h1>Matriz de versiones</h1>
+<p class="legend">Generado el {{.Generated}} con <code>next matrix</code>.</p>
+<table>
+<thead>
+<tr><th>Servicio</th>{{range .Libraries}}<th{{if .Skewed}} class="skew" title="skew: {{.UsedList}}"{{end}}>{{.Module}}</th>{{end}}</tr>
+</thead>
+<tbody>
+<tr class="latest"><td>Última</td>{{range .Libraries}}<td>{{if .Latest}}{{.Latest}}{{else}}?{{end}}</td>{{end}}</tr>
+{{range .Rows}}<tr><td>{{.Repo}}</td>{{range .Cells}}<td{{if .Class}} class="{{.Class}}"{{end}}>{{.Version}}</td>{{end}}</tr>
+{{end}}</tbody>
+</table>
+<p class="legend">En amarillo: versiones anteriores a la última publicada. Encabezado resaltado: servicios con versiones distintas (skew).</p>
+</body>
+</html>
+`))
+
+// writeMatrixHTML escribe la matriz como página HTML
+func writeMatrixHTML(w io.Writer, matrix *versionMatrix) error {
+	type htmlLibrary struct {
+		Module, Latest, UsedList string
+		Skewed                   bool
+	}
+	type htmlCell struct {
+		Version, Class string
+	}
+	type htmlRow struct {
+		Repo  string
+		Cells []htmlCell
+	}
+
+	data := struct {
+		Generated string
+		Libraries []htmlLibrary
+		Rows      []htmlRow
+	}{Generated: time.Now().Format("2006-01-02 15:04")}
+
+	for _, lib := range matrix.Libraries {
+		data.Libraries = append(data.Libraries, htmlLibrary{
+			Module:   lib.Module,
+			Latest:   lib.Latest,
+			UsedList: strings.Join(lib.Used, ", "),
+			Skewed:   lib.Skewed(),
+		})
+	}
+
+	for _, s := range matrix.Services {
+		row := htmlRow{Repo: s.Repo}
+		for _, lib := range matrix.Libraries {
+			cell := htmlCell{Version: s.Versions[lib.Base]}
+			switch {
+			case cell.Version == "":
+			case lib.Behind(cell.Version):
+				cell.Class = "behind"
+			default:
+				cell.Class = "current"
+			}
+			row.Cells = append(row.Cells, cell)
+		}
+		data.Rows = append(data.Rows, row)
+	}
+
+	return matrixHTMLTemplate.Execute(w, data)
+}
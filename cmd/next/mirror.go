@@ -0,0 +1,270 @@
+package next
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/reitmas32/next/internal/config"
+	"github.com/reitmas32/next/internal/gomod"
+	"github.com/reitmas32/next/internal/modproxy"
+	"github.com/spf13/cobra"
+)
+
+var (
+	mirrorOutput string
+	mirrorNoDeps bool
+)
+
+var mirrorCmd = &cobra.Command{
+	Use:   "mirror [módulo[@versión]...]",
+	Short: "Genera un mirror de módulos privados con el layout de GOPROXY",
+	Long: `Descarga las versiones (tags) de módulos privados usando la cuenta que
+corresponde a cada uno y genera los archivos .info, .mod y .zip con el layout
+estándar de GOPROXY. El directorio resultante funciona con GOPROXY=file:///...
+en máquinas sin acceso a GitHub/GitLab.
+
+Sin argumentos se usan las dependencias privadas del go.mod (o go.work) del
+directorio actual. Por defecto también se agregan las dependencias privadas
+de cada versión descargada (el grafo completo); use --no-deps para evitarlo.
+
+La sincronización es incremental: los repositorios se guardan en
+~/.next/cache/git y solo se generan las versiones que aún no están en el mirror.
+
+Ejemplos:
+  next mirror -o /srv/goproxy
+  next mirror github.com/mi-empresa/core-lib -o /srv/goproxy
+  next mirror github.com/mi-empresa/core-lib@v1.2.0 --no-deps
+  GOPROXY=file:///srv/goproxy GONOSUMDB=github.com/mi-empresa go mod download`,
+	RunE: runMirror,
+}
+
+func init() {
+	mirrorCmd.Flags().StringVarP(&mirrorOutput, "output", "o", "goproxy", "Directorio del mirror")
+	mirrorCmd.Flags().BoolVar(&mirrorNoDeps, "no-deps", false, "No agregar las dependencias privadas de los módulos descargados")
+
+	rootCmd.AddCommand(mirrorCmd)
+}
+
+// mirrorTarget es un módulo pendiente; Version vacía significa todas las versiones
+type mirrorTarget struct {
+	Module  string
+	Version string
+}
+
+func runMirror(cmd *cobra.Command, args []string) error {
+	cyan := color.New(color.FgCyan)
+	green := color.New(color.FgGreen)
+	yellow := color.New(color.FgYellow)
+	gray := color.New(color.FgWhite)
+
+	cfg, err := config.Load()
+	if err != nil {
+		color.Red("✗ Error al cargar configuración: %v", err)
+		return err
+	}
+
+	root, err := filepath.Abs(mirrorOutput)
+	if err != nil {
+		return err
+	}
+
+	var queue []mirrorTarget
+	for _, arg := range args {
+		module, version, _ := strings.Cut(arg, "@")
+		queue = append(queue, mirrorTarget{Module: module, Version: version})
+	}
+
+	// Sin argumentos se usan las dependencias privadas del proyecto actual
+	if len(queue) == 0 {
+		project, err := loadProject()
+		if err != nil {
+			color.Red("✗ %v", err)
+			return err
+		}
+
+		privateDeps, _, _ := resolvePrivateDependencies(cfg, project.Dependencies, "module")
+		for _, dep := range privateDeps {
+			queue = append(queue, mirrorTarget{Module: dep.Module})
+		}
+
+		if len(queue) == 0 {
+			yellow.Println("No se detectaron dependencias privadas en el proyecto")
+			return nil
+		}
+	}
+
+	fmt.Println()
+	cyan.Printf("🪞 Sincronizando mirror en %s\n", root)
+	fmt.Println()
+
+	queued := make(map[mirrorTarget]bool)
+	for _, t := range queue {
+		queued[t] = true
+	}
+
+	var mirrored []string
+	added, existing, failed := 0, 0, 0
+
+	for len(queue) > 0 {
+		target := queue[0]
+		queue = queue[1:]
+
+		cyan.Printf("• %s\n", target.Module)
+
+		src, account, err := moduleSource(cfg, target.Module)
+		if err != nil {
+			color.Red("  ✗ %v", err)
+			failed++
+			continue
+		}
+		gray.Printf("  repositorio: %s (cuenta: %s)\n", src.RepoURL, account.Name)
+
+		if err := src.Sync(); err != nil {
+			color.Red("  ✗ %v", err)
+			failed++
+			continue
+		}
+
+		versions := []string{target.Version}
+		if target.Version == "" {
+			versions, err = src.Versions()
+			if err != nil {
+				color.Red("  ✗ %v", err)
+				failed++
+				continue
+			}
+			if len(versions) == 0 {
+				yellow.Println("  ! No se encontraron tags de versión")
+				continue
+			}
+		}
+
+		var listed []string
+		for _, version := range versions {
+			if modproxy.HasVersion(root, target.Module, version) {
+				existing++
+				listed = append(listed, version)
+				continue
+			}
+
+			if err := modproxy.WriteVersion(root, src, version); err != nil {
+				color.Red("  ✗ %s: %v", version, err)
+				failed++
+				continue
+			}
+			green.Printf("  ✔ %s\n", version)
+			added++
+			listed = append(listed, version)
+		}
+
+		if err := modproxy.AddToList(root, target.Module, listed); err != nil {
+			color.Red("  ✗ Error al actualizar list: %v", err)
+			failed++
+		}
+		mirrored = append(mirrored, target.Module)
+
+		if mirrorNoDeps {
+			continue
+		}
+
+		// Agregar las dependencias privadas declaradas en cada versión
+		for _, version := range listed {
+			for _, dep := range mirrorDependencies(cfg, root, target.Module, version) {
+				t := mirrorTarget{Module: dep}
+				if !queued[t] {
+					queued[t] = true
+					queue = append(queue, t)
+				}
+			}
+		}
+	}
+
+	fmt.Println()
+	if failed > 0 {
+		yellow.Printf("! Mirror actualizado con errores: %d versiones nuevas, %d existentes, %d errores\n", added, existing, failed)
+	} else {
+		green.Printf("✔ Mirror actualizado: %d versiones nuevas, %d existentes\n", added, existing)
+	}
+
+	if len(mirrored) > 0 {
+		fmt.Println()
+		gray.Println("Uso:")
+		cyan.Printf("  GOPROXY=%s GONOSUMDB=%s go mod download\n", fileProxyURL(root), strings.Join(mirrorPatterns(mirrored), ","))
+	}
+	fmt.Println()
+
+	if failed > 0 {
+		return fmt.Errorf("%d errores al sincronizar el mirror", failed)
+	}
+	return nil
+}
+
+// moduleSource ubica el repositorio real de un módulo privado y prepara su
+// fuente de GOPROXY con la cuenta que le corresponde
+func moduleSource(cfg *config.Config, module string) (*modproxy.Source, *config.Account, error) {
+	repo, err := moduleRepository(cfg, module)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	configDir, err := config.GetConfigDir()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &modproxy.Source{
+		Module:  module,
+		RepoURL: repo.URL(),
+		Subdir:  repo.Subdir,
+		GitDir:  filepath.Join(configDir, "cache", "git", filepath.FromSlash(repo.Repo)+".git"),
+		Env:     repo.Env,
+	}, repo.Account, nil
+}
+
+// mirrorDependencies retorna las dependencias privadas del .mod de una versión del mirror
+func mirrorDependencies(cfg *config.Config, root, module, version string) []string {
+	path, err := modproxy.VersionFile(root, module, version, ".mod")
+	if err != nil {
+		return nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+
+	f, err := gomod.ParseData(data)
+	if err != nil {
+		return nil
+	}
+
+	var deps []string
+	for _, req := range f.Require {
+		if _, _, err := accountForModule(cfg, req.Path); err == nil {
+			deps = append(deps, req.Path)
+		}
+	}
+	return deps
+}
+
+// mirrorPatterns genera los patrones de GONOSUMDB (por owner) de los módulos del mirror
+func mirrorPatterns(modules []string) []string {
+	seen := make(map[string]bool)
+	var patterns []string
+	for _, module := range modules {
+		pattern := goprivatePattern(module, "owner")
+		if !seen[pattern] {
+			seen[pattern] = true
+			patterns = append(patterns, pattern)
+		}
+	}
+	return patterns
+}
+
+// fileProxyURL convierte un directorio en una URL file:// para GOPROXY
+func fileProxyURL(dir string) string {
+	return "file:///" + strings.TrimPrefix(filepath.ToSlash(dir), "/")
+}
@@ -0,0 +1,383 @@
+package next
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+	"sync"
+
+	"github.com/fatih/color"
+	"github.com/reitmas32/next/internal/api"
+	"github.com/reitmas32/next/internal/config"
+	"github.com/reitmas32/next/internal/gomod"
+	"github.com/spf13/cobra"
+)
+
+var outdatedFormat string
+
+var outdatedCmd = &cobra.Command{
+	Use:   "outdated",
+	Short: "Muestra las dependencias privadas que tienen versiones más recientes",
+	Long: `Lee el go.mod (o go.work) del directorio actual y, para cada dependencia
+privada, consulta los tags del repositorio con la cuenta que le corresponde.
+
+Para cada dependencia muestra:
+  - La versión actual
+  - La última versión compatible (mismo module path, prefiriendo releases)
+  - La última versión mayor (vN con un module path /vN distinto)
+  - Cuántas releases compatibles hay después de la actual
+
+También marca las versiones retractadas (retract en el go.mod de la última
+versión) y las versiones cuyo tag ya no existe en el repositorio.
+
+Ejemplos:
+  next outdated
+  next outdated --format json`,
+	RunE: runOutdated,
+}
+
+func init() {
+	outdatedCmd.Flags().StringVarP(&outdatedFormat, "format", "f", "table", "Formato de salida: table, json")
+
+	rootCmd.AddCommand(outdatedCmd)
+}
+
+// outdatedDependency es el estado de actualización de una dependencia privada
+type outdatedDependency struct {
+	Module            string `json:"module"`
+	Current           string `json:"current"`
+	Indirect          bool   `json:"indirect,omitempty"`
+	Account           string `json:"account,omitempty"`
+	LatestCompatible  string `json:"latest_compatible,omitempty"`
+	LatestMajor       string `json:"latest_major,omitempty"`
+	LatestMajorModule string `json:"latest_major_module,omitempty"`
+	Behind            int    `json:"behind"`
+	Update            string `json:"update,omitempty"`
+	Retracted         bool   `json:"retracted,omitempty"`
+	RetractRationale  string `json:"retract_rationale,omitempty"`
+	Deleted           bool   `json:"deleted,omitempty"`
+	Error             string `json:"error,omitempty"`
+}
+
+// outdatedWorkers limita las consultas simultáneas a los proveedores
+const outdatedWorkers = 8
+
+func runOutdated(cmd *cobra.Command, args []string) error {
+	if outdatedFormat != "table" && outdatedFormat != "json" {
+		color.Red("✗ Formato no soportado: %s (use table o json)", outdatedFormat)
+		return fmt.Errorf("formato no soportado: %s", outdatedFormat)
+	}
+
+	project, err := loadProject()
+	if err != nil {
+		color.Red("✗ %v", err)
+		return err
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		color.Red("✗ Error al cargar configuración: %v", err)
+		return err
+	}
+
+	var requires []gomod.Require
+	for _, r := range project.Requires() {
+		if _, _, err := accountForModule(cfg, r.Path); err == nil {
+			requires = append(requires, r)
+		}
+	}
+
+	if len(requires) == 0 {
+		if outdatedFormat == "json" {
+			fmt.Println("[]")
+			return nil
+		}
+		color.Yellow("No se detectaron dependencias privadas en el proyecto")
+		return nil
+	}
+
+	if outdatedFormat == "table" {
+		fmt.Println()
+		color.New(color.FgCyan).Printf("🔎 Consultando %d dependencias privadas...\n", len(requires))
+	}
+
+	providers := newProviderPool()
+	results := make([]outdatedDependency, len(requires))
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, outdatedWorkers)
+	for i, r := range requires {
+		wg.Add(1)
+		go func(i int, r gomod.Require) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			results[i] = checkOutdated(cfg, providers, r)
+		}(i, r)
+	}
+	wg.Wait()
+
+	failed := 0
+	for _, r := range results {
+		if r.Error != "" {
+			failed++
+		}
+	}
+
+	if outdatedFormat == "json" {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(results); err != nil {
+			return err
+		}
+	} else {
+		printOutdatedTable(results)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("no se pudieron consultar %d dependencias", failed)
+	}
+	return nil
+}
+
+// checkOutdated consulta los tags del repositorio de una dependencia y calcula su estado
+func checkOutdated(cfg *config.Config, providers *providerPool, req gomod.Require) outdatedDependency {
+	dep := outdatedDependency{Module: req.Path, Current: req.Version, Indirect: req.Indirect}
+
+	repo, provider, all, err := listModuleVersions(cfg, providers, req.Path)
+	if err != nil {
+		dep.Error = err.Error()
+		return dep
+	}
+	dep.Account = repo.Account.Name
+
+	compatible := repo.ModuleVersions(all)
+
+	// Las pseudo-versiones no tienen tag; las demás deben seguir existiendo
+	current := strings.TrimSuffix(req.Version, "+incompatible")
+	if !gomod.IsPseudoVersion(req.Version) && !containsVersion(all, current) {
+		dep.Deleted = true
+	}
+
+	dep.LatestCompatible = gomod.LatestVersion(compatible)
+	if dep.LatestCompatible == "" || gomod.CompareVersions(dep.LatestCompatible, req.Version) < 0 {
+		dep.LatestCompatible = req.Version
+	}
+
+	for _, v := range compatible {
+		if !gomod.IsPrerelease(v) && gomod.CompareVersions(v, current) > 0 {
+			dep.Behind++
+		}
+	}
+	dep.Update = updateLevel(current, dep.LatestCompatible)
+
+	// Versiones mayores posteriores (requieren cambiar el import path a /vN)
+	var newer []string
+	for _, v := range all {
+		if !sameModuleMajor(req.Path, v) && gomod.Major(v) > gomod.Major(current) {
+			newer = append(newer, v)
+		}
+	}
+	if latest := gomod.LatestVersion(newer); latest != "" {
+		base, _ := gomod.SplitMajorSuffix(req.Path)
+		dep.LatestMajor = latest
+		dep.LatestMajorModule = fmt.Sprintf("%s/v%d", base, gomod.Major(latest))
+	}
+
+	// Las retracciones se declaran en el go.mod de la última versión
+	if containsVersion(all, strings.TrimSuffix(dep.LatestCompatible, "+incompatible")) {
+		if mod, err := moduleGoMod(provider, repo, dep.LatestCompatible); err == nil {
+			dep.Retracted, dep.RetractRationale = mod.Retracted(req.Version)
+		}
+	}
+
+	return dep
+}
+
+// moduleGoMod lee el go.mod de una versión del módulo a través de la API del
+// proveedor (version vacía: rama por defecto)
+func moduleGoMod(provider api.Provider, repo *moduleRepo, version string) (*gomod.File, error) {
+	ref := ""
+	if version != "" {
+		ref = repo.Tag(version)
+	}
+
+	candidates := []string{path.Join(repo.Subdir, "go.mod")}
+	if _, major := gomod.SplitMajorSuffix(repo.Module); major >= 2 {
+		// Módulos /vN pueden estar en un subdirectorio vN (major subdirectory)
+		majorDir := path.Join(repo.Subdir, fmt.Sprintf("v%d", major), "go.mod")
+		candidates = append([]string{majorDir}, candidates...)
+	}
+
+	for _, file := range candidates {
+		data, err := provider.GetFile(repo.RepoPath(), file, ref)
+		if errors.Is(err, api.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		return gomod.ParseData(data)
+	}
+	return nil, api.ErrNotFound
+}
+
+// updateLevel clasifica la diferencia entre dos versiones: major, minor, patch o prerelease
+func updateLevel(from, to string) string {
+	if gomod.CompareVersions(to, from) <= 0 {
+		return ""
+	}
+
+	a, b := versionParts(from), versionParts(to)
+	switch {
+	case a[0] != b[0]:
+		return "major"
+	case a[1] != b[1]:
+		return "minor"
+	case a[2] != b[2]:
+		return "patch"
+	}
+	return "prerelease"
+}
+
+// versionParts separa vX.Y.Z-pre en [X, Y, Z]
+func versionParts(version string) [3]string {
+	core := strings.TrimPrefix(version, "v")
+	if i := strings.IndexAny(core, "-+"); i >= 0 {
+		core = core[:i]
+	}
+
+	var parts [3]string
+	copy(parts[:], strings.SplitN(core, ".", 3))
+	return parts
+}
+
+// containsVersion indica si una versión está en la lista
+func containsVersion(versions []string, version string) bool {
+	for _, v := range versions {
+		if v == version {
+			return true
+		}
+	}
+	return false
+}
+
+// printOutdatedTable muestra el reporte en una tabla con colores según el estado
+func printOutdatedTable(results []outdatedDependency) {
+	green := color.New(color.FgGreen)
+	yellow := color.New(color.FgYellow)
+	red := color.New(color.FgRed)
+	gray := color.New(color.FgWhite)
+
+	headers := []string{"MÓDULO", "ACTUAL", "COMPATIBLE", "MAYOR", "ESTADO"}
+	rows := make([][]string, len(results))
+	widths := make([]int, len(headers))
+	for i, h := range headers {
+		widths[i] = len([]rune(h))
+	}
+
+	for i, r := range results {
+		module := r.Module
+		if r.Indirect {
+			module += " (indirecta)"
+		}
+		latestMajor := r.LatestMajor
+		if latestMajor == "" {
+			latestMajor = "-"
+		}
+		rows[i] = []string{module, r.Current, r.LatestCompatible, latestMajor, outdatedStatus(r)}
+		for j, cell := range rows[i][:4] {
+			if n := len([]rune(cell)); n > widths[j] {
+				widths[j] = n
+			}
+		}
+	}
+
+	format := func(cells []string) string {
+		var b strings.Builder
+		for j, cell := range cells {
+			if j < len(cells)-1 {
+				b.WriteString(cell + strings.Repeat(" ", widths[j]-len([]rune(cell))+2))
+			} else {
+				b.WriteString(cell)
+			}
+		}
+		return b.String()
+	}
+
+	fmt.Println()
+	gray.Println(format(headers))
+
+	upToDate, outdated, flagged := 0, 0, 0
+	for i, r := range results {
+		line := format(rows[i])
+		switch {
+		case r.Error != "" || r.Retracted || r.Deleted:
+			red.Println(line)
+			flagged++
+		case r.Behind > 0 || r.LatestMajor != "":
+			yellow.Println(line)
+			outdated++
+		default:
+			green.Println(line)
+			upToDate++
+		}
+	}
+
+	// Motivos de retracción y errores debajo de la tabla
+	for _, r := range results {
+		switch {
+		case r.Error != "":
+			fmt.Println()
+			red.Printf("✗ %s: %s\n", r.Module, r.Error)
+		case r.Retracted && r.RetractRationale != "":
+			fmt.Println()
+			red.Printf("✗ %s@%s retractada: %s\n", r.Module, r.Current, r.RetractRationale)
+		}
+	}
+
+	fmt.Println()
+	summary := fmt.Sprintf("%d al día, %d desactualizadas, %d con problemas", upToDate, outdated, flagged)
+	switch {
+	case flagged > 0:
+		red.Printf("✗ %s\n", summary)
+	case outdated > 0:
+		yellow.Printf("! %s\n", summary)
+	default:
+		green.Printf("✔ %s\n", summary)
+	}
+	fmt.Println()
+}
+
+// outdatedStatus describe el estado de una dependencia en una celda de la tabla
+func outdatedStatus(r outdatedDependency) string {
+	var status []string
+	switch {
+	case r.Error != "":
+		return "error"
+	case r.Behind == 1:
+		status = append(status, fmt.Sprintf("1 versión detrás (%s)", r.Update))
+	case r.Behind > 1:
+		status = append(status, fmt.Sprintf("%d versiones detrás (%s)", r.Behind, r.Update))
+	case r.Update != "":
+		status = append(status, r.Update)
+	}
+
+	if r.Retracted {
+		status = append(status, "retractada")
+	}
+	if r.Deleted {
+		status = append(status, "tag eliminado")
+	}
+	if r.LatestMajor != "" {
+		status = append(status, "nueva mayor "+r.LatestMajorModule)
+	}
+
+	if len(status) == 0 {
+		return "al día"
+	}
+	return strings.Join(status, ", ")
+}
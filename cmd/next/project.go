@@ -0,0 +1,110 @@
+package next
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/reitmas32/next/internal/gomod"
+)
+
+// project representa el proyecto Go del directorio actual: un módulo
+// simple (go.mod) o un workspace (go.work) con varios módulos
+type project struct {
+	// Workspace es nil si no se encontró go.work
+	Workspace *gomod.Workspace
+	// Module es el go.mod del directorio actual (nil en un workspace sin módulo actual)
+	Module *gomod.File
+	// Dependencies contiene los paths de todas las dependencias sin duplicados
+	Dependencies []string
+}
+
+// loadProject detecta go.work subiendo desde el directorio actual; si no
+// existe, usa el go.mod del directorio actual
+func loadProject() (*project, error) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+
+	workPath, err := gomod.FindWorkspace(cwd)
+	if err != nil {
+		return nil, fmt.Errorf("error al buscar go.work: %w", err)
+	}
+
+	if workPath != "" {
+		ws, err := gomod.LoadWorkspace(workPath)
+		if err != nil {
+			return nil, fmt.Errorf("error al leer go.work: %w", err)
+		}
+
+		p := &project{
+			Workspace:    ws,
+			Dependencies: ws.Dependencies(),
+		}
+		if m := ws.ModuleForDir(cwd); m != nil {
+			p.Module = m.File
+		}
+		return p, nil
+	}
+
+	if _, err := os.Stat("go.mod"); os.IsNotExist(err) {
+		return nil, fmt.Errorf("no se encontró go.mod en el directorio actual")
+	}
+
+	f, err := gomod.Parse("go.mod")
+	if err != nil {
+		return nil, fmt.Errorf("error al leer go.mod: %w", err)
+	}
+
+	return &project{
+		Module:       f,
+		Dependencies: f.Paths(),
+	}, nil
+}
+
+// SumFiles retorna los archivos go.sum del proyecto (y go.work.sum en un workspace)
+func (p *project) SumFiles() []string {
+	if p.Workspace == nil {
+		return []string{"go.sum"}
+	}
+
+	files := []string{filepath.Join(p.Workspace.Dir, "go.work.sum")}
+	for _, m := range p.Workspace.Modules {
+		files = append(files, filepath.Join(m.Dir, "go.sum"))
+	}
+	return files
+}
+
+// Requires retorna los require del proyecto sin duplicados. En un workspace
+// se excluyen los propios módulos y, si varios módulos requieren el mismo
+// path, se usa la versión más alta (la que elige MVS).
+func (p *project) Requires() []gomod.Require {
+	if p.Workspace == nil {
+		return p.Module.Require
+	}
+
+	local := p.Workspace.ModulePaths()
+	index := make(map[string]int)
+
+	var requires []gomod.Require
+	for _, m := range p.Workspace.Modules {
+		for _, r := range m.File.Require {
+			if local[r.Path] {
+				continue
+			}
+			i, seen := index[r.Path]
+			switch {
+			case !seen:
+				index[r.Path] = len(requires)
+				requires = append(requires, r)
+			case gomod.CompareVersions(r.Version, requires[i].Version) > 0:
+				requires[i].Version = r.Version
+			}
+			if !r.Indirect {
+				requires[index[r.Path]].Indirect = false
+			}
+		}
+	}
+	return requires
+}
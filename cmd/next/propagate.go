@@ -0,0 +1,368 @@
+package next
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/fatih/color"
+	"github.com/reitmas32/next/internal/api"
+	"github.com/reitmas32/next/internal/config"
+	"github.com/reitmas32/next/internal/git"
+	"github.com/reitmas32/next/internal/gomod"
+	"github.com/spf13/cobra"
+)
+
+var (
+	propagateOwner       string
+	propagateAccount     string
+	propagateBranch      string
+	propagateConcurrency int
+	propagateDryRun      bool
+	propagateRefresh     bool
+)
+
+var propagateCmd = &cobra.Command{
+	Use:   "propagate <módulo>@<versión>",
+	Short: "Abre PRs/MRs que actualizan un módulo en los repositorios que lo consumen",
+	Long: `Busca los repositorios que requieren una versión anterior del módulo (igual
+que 'next dependents') y en cada uno:
+  1. Clona la rama por defecto en un directorio temporal
+  2. Crea una rama (default: next/<módulo>-<versión>)
+  3. Ejecuta 'go get <módulo>@<versión>' y 'go mod tidy' con las credenciales
+     configuradas
+  4. Hace commit de go.mod y go.sum y sube la rama
+  5. Abre un pull request (GitHub) o merge request (GitLab)
+
+La rama pertenece a next: si quedó de una ejecución anterior (ej: falló la
+creación del PR) se reemplaza con --force-with-lease y, si ya tiene un PR/MR
+abierto, se reutiliza en lugar de abrir otro.
+
+Al final muestra un resumen con el estado y el link de cada repositorio.
+
+Ejemplos:
+  next propagate github.com/mi-empresa/core-lib@v1.3.0 --dry-run
+  next propagate github.com/mi-empresa/core-lib@v1.3.0 --owner mi-empresa -j 2`,
+	Args: cobra.ExactArgs(1),
+	RunE: runPropagate,
+}
+
+func init() {
+	propagateCmd.Flags().StringVarP(&propagateOwner, "owner", "o", "", "Solo repositorios de este usuario u organización")
+	propagateCmd.Flags().StringVarP(&propagateAccount, "account", "a", "", "Solo repositorios visibles por esta cuenta")
+	propagateCmd.Flags().StringVarP(&propagateBranch, "branch", "b", "", "Nombre de la rama (default: next/<módulo>-<versión>)")
+	propagateCmd.Flags().IntVarP(&propagateConcurrency, "concurrency", "j", 4, "Repositorios procesados en paralelo")
+	propagateCmd.Flags().BoolVar(&propagateDryRun, "dry-run", false, "Solo mostrar los repositorios que se actualizarían")
+	propagateCmd.Flags().BoolVar(&propagateRefresh, "refresh", false, "Ignorar el cache de repositorios")
+
+	rootCmd.AddCommand(propagateCmd)
+}
+
+// propagateResult es el resultado de actualizar un repositorio consumidor
+type propagateResult struct {
+	Dependent dependent
+	Status    string
+	URL       string
+	Err       error
+}
+
+func runPropagate(cmd *cobra.Command, args []string) error {
+	cyan := color.New(color.FgCyan)
+	green := color.New(color.FgGreen)
+	yellow := color.New(color.FgYellow)
+	gray := color.New(color.FgWhite)
+
+	module, version, ok := strings.Cut(args[0], "@")
+	if !ok || !gomod.IsValidVersion(version) {
+		color.Red("✗ Use el formato <módulo>@<versión> (ejemplo: github.com/org/lib@v1.2.0)")
+		return fmt.Errorf("argumento inválido: %s", args[0])
+	}
+	if !sameModuleMajor(module, version) {
+		color.Red("✗ %s no corresponde al module path %s", version, module)
+		return fmt.Errorf("versión incompatible con el module path")
+	}
+	if propagateConcurrency < 1 {
+		propagateConcurrency = 1
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		color.Red("✗ Error al cargar configuración: %v", err)
+		return err
+	}
+
+	// La versión debe existir antes de pedir a otros repositorios que la usen
+	_, _, versions, err := listModuleVersions(cfg, newProviderPool(), module)
+	if err != nil {
+		color.Red("✗ %v", err)
+		return err
+	}
+	if !containsVersion(versions, version) {
+		color.Red("✗ La versión %s de %s no existe o la cuenta no tiene acceso a ella", version, module)
+		return fmt.Errorf("versión no encontrada: %s", version)
+	}
+
+	fmt.Println()
+	cyan.Printf("🔎 Buscando consumidores de %s...\n", module)
+
+	repos, errs := scanRepositories(cfg, scanOptions{
+		Account: propagateAccount,
+		Owner:   propagateOwner,
+		Refresh: propagateRefresh,
+	})
+	for _, err := range errs {
+		gray.Printf("  ! %v\n", err)
+	}
+
+	targets := outdatedDependents(findDependents(repos, module), module, version)
+	if len(targets) == 0 {
+		fmt.Println()
+		green.Printf("✔ Ningún repositorio requiere una versión anterior a %s\n", version)
+		fmt.Println()
+		return nil
+	}
+
+	branch := propagateBranch
+	if branch == "" {
+		branch = defaultPropagateBranch(module, version)
+	}
+
+	fmt.Println()
+	cyan.Printf("📦 %d repositorios a actualizar a %s (rama %s)\n", len(targets), version, branch)
+	fmt.Println()
+
+	results := make([]propagateResult, len(targets))
+	if propagateDryRun {
+		for i, d := range targets {
+			results[i] = propagateResult{Dependent: d, Status: "pendiente (dry-run)"}
+		}
+	} else {
+		var wg sync.WaitGroup
+		sem := make(chan struct{}, propagateConcurrency)
+		for i, d := range targets {
+			wg.Add(1)
+			go func(i int, d dependent) {
+				defer wg.Done()
+				sem <- struct{}{}
+				defer func() { <-sem }()
+
+				results[i] = propagateRepo(cfg, d, module, version, branch)
+				if results[i].Err != nil {
+					color.Red("  ✗ %s: %v", d.Repo, results[i].Err)
+				} else {
+					gray.Printf("  ✔ %s: %s\n", d.Repo, results[i].Status)
+				}
+			}(i, d)
+		}
+		wg.Wait()
+		fmt.Println()
+	}
+
+	failed := printPropagateSummary(results, version)
+
+	fmt.Println()
+	switch {
+	case propagateDryRun:
+		yellow.Println("! --dry-run: no se modificó ningún repositorio")
+	case failed > 0:
+		yellow.Printf("! %d de %d repositorios con errores\n", failed, len(results))
+	default:
+		green.Printf("✔ %d repositorios actualizados\n", len(results))
+	}
+	fmt.Println()
+
+	if failed > 0 {
+		return fmt.Errorf("%d repositorios no se pudieron actualizar", failed)
+	}
+	return nil
+}
+
+// outdatedDependents deja un consumidor por repositorio que requiera el mismo
+// module path en una versión anterior
+func outdatedDependents(dependents []dependent, module, version string) []dependent {
+	seen := make(map[string]bool)
+	var targets []dependent
+	for _, d := range dependents {
+		if d.Path != module || seen[d.Repo] || gomod.CompareVersions(d.Version, version) >= 0 {
+			continue
+		}
+		seen[d.Repo] = true
+		targets = append(targets, d)
+	}
+	return targets
+}
+
+// propagateRepo actualiza el módulo en un repositorio consumidor y abre el PR/MR
+func propagateRepo(cfg *config.Config, d dependent, module, version, branch string) propagateResult {
+	result := propagateResult{Dependent: d}
+	fail := func(err error) propagateResult {
+		result.Status = "error"
+		result.Err = err
+		return result
+	}
+
+	account, err := cfg.GetAccount(d.Account)
+	if err != nil {
+		return fail(err)
+	}
+
+	domain, _, _ := strings.Cut(d.Repo, "/")
+	scheme, gitEnv := accountGitEnv(account, domain)
+
+	dir, err := os.MkdirTemp("", "next-propagate-*")
+	if err != nil {
+		return fail(err)
+	}
+	defer os.RemoveAll(dir)
+
+	if err := git.CloneShallow(scheme+"://"+d.Repo+".git", dir, gitEnv); err != nil {
+		return fail(err)
+	}
+
+	base, err := git.CurrentBranchIn(dir)
+	if err != nil {
+		return fail(err)
+	}
+	if branch == base {
+		return fail(fmt.Errorf("la rama %s es la rama por defecto del repositorio", branch))
+	}
+	if err := git.CreateBranchIn(dir, branch); err != nil {
+		return fail(err)
+	}
+
+	goEnv, err := consumerGoEnv(cfg, dir, module)
+	if err != nil {
+		return fail(err)
+	}
+	if err := goCommandIn(dir, goEnv, "get", module+"@"+version); err != nil {
+		return fail(err)
+	}
+	if err := goCommandIn(dir, goEnv, "mod", "tidy"); err != nil {
+		return fail(err)
+	}
+
+	changed, err := git.HasChangesIn(dir)
+	if err != nil {
+		return fail(err)
+	}
+	if !changed {
+		result.Status = "sin cambios"
+		return result
+	}
+
+	title := fmt.Sprintf("Actualiza %s a %s", module, version)
+	if err := git.CommitAllIn(dir, title); err != nil {
+		return fail(err)
+	}
+
+	// La rama puede existir por una ejecución anterior; se reemplaza solo si
+	// nadie la modificó desde que se consultó
+	remote, err := git.RemoteBranchIn(dir, branch, gitEnv)
+	if err != nil {
+		return fail(err)
+	}
+	if err := git.PushBranchIn(dir, branch, remote, gitEnv); err != nil {
+		return fail(err)
+	}
+
+	provider, err := api.NewProvider(account.Provider, account.Domain, account.Token)
+	if err != nil {
+		return fail(err)
+	}
+
+	existing, err := provider.FindPullRequest(d.RepoPath, branch)
+	if err != nil {
+		return fail(err)
+	}
+	if existing != "" {
+		result.Status = "actualizado"
+		result.URL = existing
+		return result
+	}
+
+	url, err := provider.CreatePullRequest(d.RepoPath, api.PullRequest{
+		Title: title,
+		Body:  fmt.Sprintf("Actualiza `%s` de %s a %s.\n\nGenerado con `next propagate`.", module, d.Version, version),
+		Head:  branch,
+		Base:  base,
+	})
+	if err != nil {
+		return fail(err)
+	}
+
+	result.Status = "abierto"
+	result.URL = url
+	return result
+}
+
+// consumerGoEnv calcula las credenciales para las dependencias privadas del
+// consumidor (más el módulo que se actualiza)
+func consumerGoEnv(cfg *config.Config, dir, module string) ([]string, error) {
+	f, err := gomod.Parse(filepath.Join(dir, "go.mod"))
+	if err != nil {
+		return nil, fmt.Errorf("error al leer go.mod: %w", err)
+	}
+
+	privateDeps, goprivatePatterns, goinsecurePatterns := resolvePrivateDependencies(cfg, append(f.Paths(), module), "module")
+	return credentialEnv(privateDeps, goprivatePatterns, goinsecurePatterns, true)
+}
+
+// goCommandIn ejecuta un comando go en dir con las variables extra
+func goCommandIn(dir string, env []string, args ...string) error {
+	cmd := exec.Command("go", args...)
+	cmd.Dir = dir
+	cmd.Env = mergeEnv(os.Environ(), env)
+
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("go %s: %s", strings.Join(args, " "), strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// printPropagateSummary muestra el estado de cada repositorio y retorna cuántos fallaron
+func printPropagateSummary(results []propagateResult, version string) int {
+	green := color.New(color.FgGreen)
+	yellow := color.New(color.FgYellow)
+	gray := color.New(color.FgWhite)
+
+	width := 0
+	for _, r := range results {
+		if len(r.Dependent.Repo) > width {
+			width = len(r.Dependent.Repo)
+		}
+	}
+
+	failed := 0
+	for _, r := range results {
+		line := fmt.Sprintf("  %-*s  %s → %s  %s", width, r.Dependent.Repo, r.Dependent.Version, version, r.Status)
+		switch {
+		case r.Err != nil:
+			color.Red("%s", line)
+			failed++
+		case r.URL != "":
+			green.Println(line)
+			gray.Printf("      %s\n", r.URL)
+		case propagateDryRun:
+			yellow.Println(line)
+		default:
+			gray.Println(line)
+		}
+	}
+	return failed
+}
+
+// defaultPropagateBranch retorna next/<librería>-<versión>; para un módulo /vN
+// la librería es el último elemento sin el sufijo más la mayor
+// (…/core-lib/v2 -> next/core-lib-v2-v2.1.0)
+func defaultPropagateBranch(module, version string) string {
+	base, major := gomod.SplitMajorSuffix(module)
+	name := path.Base(base)
+	if major >= 2 {
+		name += fmt.Sprintf("-v%d", major)
+	}
+	return fmt.Sprintf("next/%s-%s", name, version)
+}
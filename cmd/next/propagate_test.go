@@ -0,0 +1,18 @@
+package next
+
+import "testing"
+
+func TestDefaultPropagateBranch(t *testing.T) {
+	for _, tc := range []struct {
+		module, version, want string
+	}{
+		{"github.com/mi-empresa/core-lib", "v1.3.0", "next/core-lib-v1.3.0"},
+		{"github.com/mi-empresa/core-lib/v2", "v2.1.0", "next/core-lib-v2-v2.1.0"},
+		{"github.com/mi-empresa/retry/v3", "v3.0.0", "next/retry-v3-v3.0.0"},
+		{"gopkg.in/yaml.v3", "v3.0.1", "next/yaml.v3-v3.0.1"},
+	} {
+		if got := defaultPropagateBranch(tc.module, tc.version); got != tc.want {
+			t.Errorf("defaultPropagateBranch(%q, %q) = %q, se esperaba %q", tc.module, tc.version, got, tc.want)
+		}
+	}
+}
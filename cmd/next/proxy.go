@@ -0,0 +1,195 @@
+package next
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/reitmas32/next/internal/config"
+	"github.com/reitmas32/next/internal/modproxy"
+	"github.com/spf13/cobra"
+)
+
+var (
+	proxyAddr      string
+	proxyUpstream  string
+	proxyCacheDir  string
+	proxySyncEvery time.Duration
+	proxyAllow     []string
+)
+
+var proxyCmd = &cobra.Command{
+	Use:   "proxy",
+	Short: "Proxy de módulos Go autenticado con las cuentas configuradas",
+}
+
+var proxyServeCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Inicia un GOPROXY local que sirve módulos privados",
+	Long: `Inicia un servidor que implementa el protocolo HTTP de GOPROXY. Los módulos
+privados se sirven desde los repositorios de las cuentas configuradas (la
+cuenta se elige con GetAccountForModule, igual que 'next check') y los zips
+generados se guardan en disco. Los módulos públicos se reenvían al upstream.
+
+Así no es necesario configurar credenciales de git en cada máquina: basta con
+apuntar GOPROXY al servidor. Como sum.golang.org no conoce los módulos
+privados, GONOSUMDB debe incluirlos (no use GOPRIVATE: también desactivaría
+el proxy para ellos).
+
+El servidor no autentica a sus clientes: cualquiera que pueda conectarse
+descarga el código privado de las cuentas. Por eso escucha solo en localhost
+por defecto; para escuchar en otra dirección hay que indicar con --allow las
+redes que pueden usarlo.
+
+Ejemplos:
+  next proxy serve
+  next proxy serve --addr :7070 --allow 10.0.0.0/8 --upstream off
+  GOPROXY=http://localhost:7070 GONOSUMDB=github.com/mi-empresa go mod download`,
+	RunE: runProxyServe,
+}
+
+func init() {
+	proxyServeCmd.Flags().StringVar(&proxyAddr, "addr", "localhost:7070", "Dirección donde escuchar")
+	proxyServeCmd.Flags().StringVar(&proxyUpstream, "upstream", "https://proxy.golang.org", "Proxy para módulos públicos ('off' para responder 404)")
+	proxyServeCmd.Flags().StringVar(&proxyCacheDir, "cache", "", "Directorio del cache de módulos (default: ~/.next/cache/proxy)")
+	proxyServeCmd.Flags().StringSliceVar(&proxyAllow, "allow", nil, "Redes o IPs (además de loopback) que pueden usar el proxy (ej: 10.0.0.0/8,192.168.1.20)")
+	proxyServeCmd.Flags().DurationVar(&proxySyncEvery, "sync-interval", time.Minute, "Tiempo mínimo entre consultas de tags nuevos por repositorio")
+
+	proxyCmd.AddCommand(proxyServeCmd)
+	rootCmd.AddCommand(proxyCmd)
+}
+
+func runProxyServe(cmd *cobra.Command, args []string) error {
+	cyan := color.New(color.FgCyan)
+	green := color.New(color.FgGreen)
+	gray := color.New(color.FgWhite)
+
+	cfg, err := config.Load()
+	if err != nil {
+		color.Red("✗ Error al cargar configuración: %v", err)
+		return err
+	}
+
+	if len(cfg.Accounts) == 0 {
+		color.Red("✗ No hay cuentas configuradas. Use 'next login' para agregar una")
+		return fmt.Errorf("no hay cuentas configuradas")
+	}
+
+	allow, err := parseAllowedNetworks(proxyAllow)
+	if err != nil {
+		color.Red("✗ %v", err)
+		return err
+	}
+
+	if !isLoopbackAddr(proxyAddr) && len(allow) == 0 {
+		color.Red("✗ %s no es una dirección local y el proxy sirve código privado sin autenticación", proxyAddr)
+		gray.Println("  Indique con --allow las redes que pueden usarlo (ej: --allow 10.0.0.0/8)")
+		return fmt.Errorf("--addr %s requiere --allow", proxyAddr)
+	}
+
+	cacheDir := proxyCacheDir
+	if cacheDir == "" {
+		configDir, err := config.GetConfigDir()
+		if err != nil {
+			return err
+		}
+		cacheDir = filepath.Join(configDir, "cache", "proxy")
+	}
+
+	upstream := proxyUpstream
+	if upstream == "off" {
+		upstream = ""
+	}
+
+	server := &modproxy.Server{
+		CacheDir:     cacheDir,
+		Upstream:     upstream,
+		SyncInterval: proxySyncEvery,
+		Allow:        allow,
+		Resolve: func(module string) (*modproxy.Source, error) {
+			// Los módulos sin cuenta son públicos y se reenvían al upstream
+			if _, _, err := accountForModule(cfg, module); err != nil {
+				return nil, nil
+			}
+			src, _, err := moduleSource(cfg, module)
+			return src, err
+		},
+		Logf: func(format string, args ...interface{}) {
+			gray.Printf(time.Now().Format("15:04:05")+" "+format+"\n", args...)
+		},
+	}
+
+	fmt.Println()
+	cyan.Printf("🚀 GOPROXY escuchando en http://%s\n", proxyAddr)
+	gray.Printf("Cache: %s\n", cacheDir)
+	if len(allow) > 0 {
+		color.Yellow("! Sin autenticación: cualquier cliente de %s puede descargar los módulos privados", strings.Join(proxyAllow, ", "))
+	}
+	if upstream != "" {
+		gray.Printf("Upstream: %s\n", upstream)
+	} else {
+		gray.Println("Upstream: desactivado")
+	}
+	fmt.Println()
+	green.Println("Configure en cada máquina:")
+	cyan.Printf("  go env -w GOPROXY=http://%s GONOSUMDB=%s\n", proxyAddr, strings.Join(accountPatterns(cfg), ","))
+	fmt.Println()
+
+	return http.ListenAndServe(proxyAddr, server)
+}
+
+// isLoopbackAddr indica si una dirección host:puerto solo acepta conexiones locales
+func isLoopbackAddr(addr string) bool {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return false
+	}
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// parseAllowedNetworks convierte los valores de --allow (CIDR o IP) en redes
+func parseAllowedNetworks(values []string) ([]*net.IPNet, error) {
+	var networks []*net.IPNet
+	for _, value := range values {
+		if !strings.Contains(value, "/") {
+			ip := net.ParseIP(value)
+			if ip == nil {
+				return nil, fmt.Errorf("--allow: %q no es una IP ni una red CIDR", value)
+			}
+			bits := 128
+			if ip.To4() != nil {
+				bits = 32
+			}
+			networks = append(networks, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+
+		_, network, err := net.ParseCIDR(value)
+		if err != nil {
+			return nil, fmt.Errorf("--allow: %q no es una IP ni una red CIDR", value)
+		}
+		networks = append(networks, network)
+	}
+	return networks, nil
+}
+
+// accountPatterns genera patrones por owner (o dominio para cuentas wildcard) de todas las cuentas
+func accountPatterns(cfg *config.Config) []string {
+	seen := make(map[string]bool)
+	var patterns []string
+	for _, prefix := range accountPrefixes(cfg) {
+		if !seen[prefix] {
+			seen[prefix] = true
+			patterns = append(patterns, prefix)
+		}
+	}
+	return patterns
+}
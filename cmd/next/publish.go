@@ -0,0 +1,285 @@
+package next
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/reitmas32/next/internal/config"
+	"github.com/reitmas32/next/internal/git"
+	"github.com/reitmas32/next/internal/gomod"
+	"github.com/reitmas32/next/internal/modproxy"
+	"github.com/reitmas32/next/internal/publish"
+	"github.com/reitmas32/next/internal/sumdb"
+	"github.com/spf13/cobra"
+)
+
+var (
+	publishTarget   string
+	publishToken    string
+	publishUsername string
+	publishDryRun   bool
+)
+
+var publishCmd = &cobra.Command{
+	Use:   "publish <versión>",
+	Short: "Publica el zip, .mod e .info de una versión en un registro de módulos",
+	Long: `Genera los archivos .info, .mod y .zip de una versión (tag) del módulo del
+directorio actual y los sube a un registro. El zip cumple las reglas del
+comando go: límites de tamaño (500 MiB de contenido, 16 MiB para go.mod y
+LICENSE), excluye vendor/, submódulos con su propio go.mod y archivos que no
+son regulares, y rechaza rutas inválidas o que solo difieren en mayúsculas.
+
+El tag se toma del repositorio local (o se trae de origin). En un workspace o
+un submódulo se usa el tag con prefijo (ej: sub/v1.0.0).
+
+Destinos (--target):
+  dir:<ruta>          Directorio con el layout de GOPROXY (para pruebas)
+  https://<url>       Registro HTTP genérico (ej: repositorio Go de Artifactory);
+                      sube con PUT a <url>/<módulo>/@v/<versión>.{mod,info,zip}
+  gitlab[:<proyecto>] Registro de paquetes genéricos del proyecto de GitLab
+                      (por defecto el de origin)
+
+Autenticación para registros HTTP: --token (o NEXT_PUBLISH_TOKEN) como Bearer,
+o Basic con --username. Sin token se usa la cuenta de next del dominio.
+
+Ejemplos:
+  next publish v1.2.0 --target dir:/tmp/goproxy
+  next publish v1.2.0 --target https://artifactory.empresa.com/artifactory/api/go/go-local
+  next publish v1.2.0 --target gitlab
+  next publish v1.2.0 --dry-run`,
+	Args: cobra.ExactArgs(1),
+	RunE: runPublish,
+}
+
+func init() {
+	publishCmd.Flags().StringVarP(&publishTarget, "target", "t", "", "Destino: dir:<ruta>, https://<url> o gitlab[:<proyecto>]")
+	publishCmd.Flags().StringVar(&publishToken, "token", "", "Token del registro HTTP (default: $NEXT_PUBLISH_TOKEN)")
+	publishCmd.Flags().StringVar(&publishUsername, "username", "", "Usuario para autenticación Basic en el registro HTTP")
+	publishCmd.Flags().BoolVar(&publishDryRun, "dry-run", false, "Solo generar y validar los archivos, sin subirlos")
+
+	rootCmd.AddCommand(publishCmd)
+}
+
+func runPublish(cmd *cobra.Command, args []string) error {
+	version := args[0]
+
+	cyan := color.New(color.FgCyan)
+	green := color.New(color.FgGreen)
+	gray := color.New(color.FgWhite)
+
+	if !gomod.IsValidVersion(version) {
+		color.Red("✗ Versión inválida: %s", version)
+		color.Yellow("  Use el formato: vX.Y.Z (ejemplo: v1.0.0)")
+		return fmt.Errorf("versión inválida")
+	}
+
+	if publishTarget == "" && !publishDryRun {
+		color.Red("✗ Indique el destino con --target (o use --dry-run)")
+		return fmt.Errorf("destino no especificado")
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		color.Red("✗ Error al cargar configuración: %v", err)
+		return err
+	}
+
+	src, err := localModuleSource()
+	if err != nil {
+		color.Red("✗ %v", err)
+		return err
+	}
+
+	tag := strings.TrimSuffix(version, "+incompatible")
+	if src.Subdir != "" {
+		tag = src.Subdir + "/" + tag
+	}
+
+	var publisher publish.Publisher
+	if !publishDryRun {
+		if publisher, err = publisherForTarget(cfg, publishTarget); err != nil {
+			color.Red("✗ %v", err)
+			return err
+		}
+	}
+
+	fmt.Println()
+	cyan.Printf("📦 %s@%s (tag %s)\n", src.Module, version, tag)
+
+	// El tag puede existir solo en el remote (ej: creado con 'next create-version')
+	if _, err := git.BareCommitTime(src.GitDir, "refs/tags/"+tag); err != nil {
+		gray.Printf("  Trayendo tag %s de origin...\n", tag)
+		if err := git.FetchTag("origin", tag); err != nil {
+			color.Red("✗ %v", err)
+			return err
+		}
+	}
+
+	build, err := src.Build(version)
+	if err != nil {
+		color.Red("✗ %v", err)
+		return err
+	}
+
+	hash, err := sumdb.HashZip(build.Zip)
+	if err != nil {
+		color.Red("✗ %v", err)
+		return err
+	}
+
+	green.Printf("✔ Zip generado: %s\n", formatSize(len(build.Zip)))
+	gray.Printf("  %s %s %s\n", src.Module, version, hash)
+
+	if publishDryRun {
+		fmt.Println()
+		color.Yellow("! --dry-run: no se subió ningún archivo")
+		fmt.Println()
+		return nil
+	}
+
+	cyan.Printf("📤 Publicando en %s...\n", publisher.Name())
+	if err := publisher.Publish(build); err != nil {
+		color.Red("✗ Error al publicar: %v", err)
+		return err
+	}
+
+	fmt.Println()
+	green.Printf("✔ %s@%s publicado\n", src.Module, version)
+	fmt.Println()
+
+	return nil
+}
+
+// localModuleSource ubica el módulo del directorio actual dentro del
+// repositorio y retorna su fuente a partir del repositorio local
+func localModuleSource() (*modproxy.Source, error) {
+	repoRoot, err := git.GetRepoRoot()
+	if err != nil {
+		return nil, fmt.Errorf("no se encuentra en un repositorio Git")
+	}
+
+	project, err := loadProject()
+	if err != nil {
+		return nil, err
+	}
+	if project.Module == nil {
+		return nil, fmt.Errorf("el directorio actual no pertenece a ningún módulo del workspace")
+	}
+
+	moduleDir, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+	if project.Workspace != nil {
+		moduleDir = project.Workspace.ModuleForDir(moduleDir).Dir
+	}
+
+	rel, err := filepath.Rel(repoRoot, moduleDir)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return nil, fmt.Errorf("el módulo %s no está dentro del repositorio actual", project.Module.Module)
+	}
+
+	// Un módulo /vN en el subdirectorio vN usa los tags del directorio padre
+	subdir := strings.TrimSuffix(gomod.TagPrefix(project.Module.Module, filepath.ToSlash(rel)), "/")
+
+	gitDir, err := git.GetGitCommonDir()
+	if err != nil {
+		return nil, err
+	}
+
+	src := &modproxy.Source{Module: project.Module.Module, Subdir: subdir, GitDir: gitDir}
+	return src, nil
+}
+
+// publisherForTarget crea el publisher que corresponde al destino indicado
+func publisherForTarget(cfg *config.Config, target string) (publish.Publisher, error) {
+	switch {
+	case strings.HasPrefix(target, "dir:"):
+		root, err := filepath.Abs(strings.TrimPrefix(target, "dir:"))
+		if err != nil {
+			return nil, err
+		}
+		return publish.NewDirPublisher(root), nil
+
+	case target == "gitlab" || strings.HasPrefix(target, "gitlab:"):
+		return gitlabPublisher(cfg, strings.TrimPrefix(strings.TrimPrefix(target, "gitlab"), ":"))
+
+	case strings.HasPrefix(target, "https://") || strings.HasPrefix(target, "http://"):
+		u, err := url.Parse(target)
+		if err != nil {
+			return nil, fmt.Errorf("URL inválida: %s", target)
+		}
+		return publish.NewHTTPPublisher(target, registryAuthorization(cfg, u.Host)), nil
+	}
+
+	return nil, fmt.Errorf("destino no soportado: %s (use dir:<ruta>, https://<url> o gitlab)", target)
+}
+
+// gitlabPublisher publica en el proyecto indicado o en el de origin, con la
+// cuenta que corresponde al remote
+func gitlabPublisher(cfg *config.Config, project string) (publish.Publisher, error) {
+	remoteURL, err := git.GetRemoteURL("origin")
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener remote origin: %w", err)
+	}
+
+	provider, domain, repoPath, err := git.ParseRemoteURL(remoteURL)
+	if err != nil {
+		return nil, fmt.Errorf("error al parsear URL del remote: %w", err)
+	}
+	if provider != "gitlab" {
+		return nil, fmt.Errorf("origin (%s) no es un repositorio de GitLab", domain)
+	}
+
+	if project == "" {
+		project = repoPath
+	}
+
+	account, err := cfg.GetAccountByDomainAndOwner(domain, extractOwnerFromRepoPath(project))
+	if err != nil {
+		return nil, fmt.Errorf("no se encontró cuenta para %s/%s", domain, extractOwnerFromRepoPath(project))
+	}
+
+	baseURL := account.Domain
+	if !strings.HasPrefix(baseURL, "http://") && !strings.HasPrefix(baseURL, "https://") {
+		baseURL = "https://" + baseURL
+	}
+	return publish.NewGitLabPublisher(baseURL, project, account.Token), nil
+}
+
+// registryAuthorization arma el header Authorization de un registro HTTP:
+// --token/NEXT_PUBLISH_TOKEN (Bearer o Basic con --username) o la cuenta del dominio
+func registryAuthorization(cfg *config.Config, host string) string {
+	token := publishToken
+	if token == "" {
+		token = os.Getenv("NEXT_PUBLISH_TOKEN")
+	}
+
+	if token != "" {
+		if publishUsername != "" {
+			return "Basic " + base64.StdEncoding.EncodeToString([]byte(publishUsername+":"+token))
+		}
+		return "Bearer " + token
+	}
+
+	if account, err := cfg.GetAccountByDomain(host); err == nil {
+		return basicAuthorization(account)
+	}
+	return ""
+}
+
+// formatSize muestra un tamaño en bytes de forma legible
+func formatSize(n int) string {
+	switch {
+	case n >= 1<<20:
+		return fmt.Sprintf("%.1f MiB", float64(n)/(1<<20))
+	case n >= 1<<10:
+		return fmt.Sprintf("%.1f KiB", float64(n)/(1<<10))
+	}
+	return fmt.Sprintf("%d B", n)
+}
@@ -0,0 +1,241 @@
+package next
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/reitmas32/next/internal/api"
+	"github.com/reitmas32/next/internal/config"
+	"github.com/reitmas32/next/internal/git"
+	"github.com/reitmas32/next/internal/gomod"
+)
+
+// moduleRepo es el repositorio que contiene un módulo privado
+type moduleRepo struct {
+	Module  string
+	Account *config.Account
+	// Repo es host/path del repositorio (ej: github.com/org/lib)
+	Repo string
+	// Subdir es el directorio del módulo dentro del repositorio (vacío en la raíz)
+	Subdir string
+	// Scheme es http o https según el dominio de la cuenta
+	Scheme string
+	// Env contiene las credenciales de la cuenta para git
+	Env []string
+}
+
+// RepoPath retorna el path del repositorio en el proveedor (ej: org/lib)
+func (r *moduleRepo) RepoPath() string {
+	_, path, _ := strings.Cut(r.Repo, "/")
+	return path
+}
+
+// URL retorna la URL de clonado del repositorio
+func (r *moduleRepo) URL() string {
+	return r.Scheme + "://" + r.Repo + ".git"
+}
+
+// TagPrefix retorna el prefijo de los tags del módulo (ej: "sub/" para sub/v1.0.0)
+func (r *moduleRepo) TagPrefix() string {
+	if r.Subdir == "" {
+		return ""
+	}
+	return r.Subdir + "/"
+}
+
+// Tag retorna el tag de una versión del módulo
+func (r *moduleRepo) Tag(version string) string {
+	return r.TagPrefix() + strings.TrimSuffix(version, "+incompatible")
+}
+
+// TagVersions retorna las versiones semver de los tags con el prefijo del
+// módulo (de cualquier versión mayor), ordenadas de menor a mayor
+func (r *moduleRepo) TagVersions(tags []string) []string {
+	prefix := r.TagPrefix()
+
+	var versions []string
+	for _, tag := range tags {
+		if !strings.HasPrefix(tag, prefix) {
+			continue
+		}
+		version := strings.TrimPrefix(tag, prefix)
+		if gomod.IsValidVersion(version) && !strings.Contains(version, "+") {
+			versions = append(versions, version)
+		}
+	}
+
+	sort.Slice(versions, func(i, j int) bool {
+		return gomod.CompareVersions(versions[i], versions[j]) < 0
+	})
+	return versions
+}
+
+// ModuleVersions filtra las versiones que pertenecen al module path: v0/v1
+// sin sufijo y vN con sufijo /vN
+func (r *moduleRepo) ModuleVersions(versions []string) []string {
+	var matching []string
+	for _, v := range versions {
+		if sameModuleMajor(r.Module, v) {
+			matching = append(matching, v)
+		}
+	}
+	return matching
+}
+
+// sameModuleMajor indica si una versión corresponde al sufijo /vN del module path
+func sameModuleMajor(module, version string) bool {
+	_, moduleMajor := gomod.SplitMajorSuffix(module)
+	major := gomod.Major(version)
+	if moduleMajor == 0 {
+		return major == 0 || major == 1
+	}
+	return major == moduleMajor
+}
+
+// repoLookup ajusta cómo lookupModuleRepository ubica el repositorio
+type repoLookup struct {
+	// Account fuerza la cuenta (nil: la que corresponde al módulo)
+	Account *config.Account
+	// Offline resuelve los paths vanity solo desde el cache
+	Offline bool
+	// Exists reemplaza a git ls-remote para saber si un candidato a raíz
+	// del repositorio (host/path) existe (ej: con el cache de la API)
+	Exists func(account *config.Account, repo string) bool
+}
+
+// moduleRepository ubica el repositorio real de un módulo privado (resolviendo
+// paths vanity, subgrupos de GitLab, subdirectorios y sufijos /vN) y la cuenta
+// que le corresponde
+func moduleRepository(cfg *config.Config, module string) (*moduleRepo, error) {
+	return lookupModuleRepository(cfg, module, repoLookup{})
+}
+
+// lookupModuleRepository es moduleRepository con las opciones de lookup
+func lookupModuleRepository(cfg *config.Config, module string, lookup repoLookup) (*moduleRepo, error) {
+	account, location, err := locateModule(cfg, module, lookup.Account, lookup.Offline)
+	if err != nil {
+		return nil, err
+	}
+
+	scheme, env := accountGitEnv(account, location.Domain)
+
+	exists := func(candidate string) bool {
+		if lookup.Exists != nil {
+			return lookup.Exists(account, candidate)
+		}
+		return git.RemoteExists(scheme+"://"+candidate+".git", env)
+	}
+
+	basePath, _ := gomod.SplitMajorSuffix(module)
+	prefix, repo := location.Prefix, location.Repo
+	if repo == "" {
+		prefix, err = repositoryRoot(account, basePath, exists)
+		if err != nil {
+			return nil, err
+		}
+		repo = prefix
+	}
+
+	// Subdirectorio del módulo dentro del repositorio (ej: sub/ para tags sub/v1.0.0)
+	subdir := ""
+	if strings.HasPrefix(basePath, prefix+"/") {
+		subdir = strings.TrimPrefix(basePath, prefix+"/")
+	}
+
+	return &moduleRepo{
+		Module:  module,
+		Account: account,
+		Repo:    repo,
+		Subdir:  subdir,
+		Scheme:  scheme,
+		Env:     env,
+	}, nil
+}
+
+// accountGitEnv retorna el esquema de la cuenta y el entorno de git que
+// autentica las peticiones HTTP al dominio con su token. Las credenciales van
+// en el entorno, nunca en la URL ni en argumentos.
+func accountGitEnv(account *config.Account, domain string) (string, []string) {
+	scheme := "https"
+	if strings.HasPrefix(account.Domain, "http://") {
+		scheme = "http"
+	}
+
+	env := gitConfigEnv([]gitConfigEntry{{
+		Key:   fmt.Sprintf("http.%s://%s/.extraHeader", scheme, domain),
+		Value: "Authorization: " + basicAuthorization(account),
+	}})
+	return scheme, env
+}
+
+// repositoryRoot retorna el import path de la raíz del repositorio de un módulo
+// En GitHub es dominio/owner/repo; en GitLab los subgrupos permiten paths más
+// largos, así que se prueba con exists desde el path completo hacia dominio/owner/repo
+func repositoryRoot(account *config.Account, basePath string, exists func(candidate string) bool) (string, error) {
+	parts := strings.Split(basePath, "/")
+	if len(parts) < 3 {
+		return "", fmt.Errorf("module path inválido: %s", basePath)
+	}
+
+	if account.Provider != "gitlab" || len(parts) == 3 {
+		return strings.Join(parts[:3], "/"), nil
+	}
+
+	for n := len(parts); n >= 3; n-- {
+		candidate := strings.Join(parts[:n], "/")
+		if exists(candidate) {
+			return candidate, nil
+		}
+	}
+
+	return "", fmt.Errorf("no se encontró el repositorio de %s", basePath)
+}
+
+// listModuleVersions consulta con la API del proveedor los tags del repositorio
+// de un módulo y retorna sus versiones (de cualquier versión mayor)
+func listModuleVersions(cfg *config.Config, providers *providerPool, module string) (*moduleRepo, api.Provider, []string, error) {
+	repo, err := moduleRepository(cfg, module)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	provider, err := providers.get(repo.Account)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	tags, err := provider.ListTags(repo.RepoPath())
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return repo, provider, repo.TagVersions(tags), nil
+}
+
+// providerPool reutiliza un cliente por cuenta entre goroutines
+type providerPool struct {
+	mu        sync.Mutex
+	providers map[string]api.Provider
+}
+
+func newProviderPool() *providerPool {
+	return &providerPool{providers: make(map[string]api.Provider)}
+}
+
+// get retorna el cliente del proveedor de una cuenta, creándolo si no existe
+func (p *providerPool) get(account *config.Account) (api.Provider, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if provider, ok := p.providers[account.Name]; ok {
+		return provider, nil
+	}
+
+	provider, err := api.NewProvider(account.Provider, account.Domain, account.Token)
+	if err != nil {
+		return nil, err
+	}
+	p.providers[account.Name] = provider
+	return provider, nil
+}
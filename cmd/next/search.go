@@ -0,0 +1,501 @@
+package next
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/fatih/color"
+	"github.com/reitmas32/next/internal/api"
+	"github.com/reitmas32/next/internal/cache"
+	"github.com/reitmas32/next/internal/config"
+	"github.com/spf13/cobra"
+)
+
+var (
+	searchOwner    string
+	searchAccount  string
+	searchTopics   []string
+	searchLanguage string
+	searchSymbol   bool
+	searchLimit    int
+	searchRefresh  bool
+)
+
+var searchCmd = &cobra.Command{
+	Use:   "search <consulta>",
+	Short: "Busca librerías por nombre, descripción, topic o símbolo",
+	Long: `Busca en el catálogo de librerías Go de todas las cuentas configuradas.
+
+El nombre y la descripción se comparan de forma aproximada: coincidencias
+exactas, prefijos, subcadenas, letras en orden (rtry → retry) y errores de
+tipeo. Los resultados se ordenan por relevancia.
+
+Con --symbol la consulta es un identificador Go exportado (ej: NewClient) y se
+busca su declaración (func, type, var, const, también dentro de bloques
+const ( ... )) con la búsqueda de código del proveedor en los repositorios
+del catálogo.
+
+El catálogo se guarda en ~/.next/cache/repos durante una hora; use --refresh
+para consultarlo de nuevo.
+
+Ejemplos:
+  next search retry
+  next search "http client" --topic resilience
+  next search cache --language go --owner mi-empresa
+  next search NewClient --symbol`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSearch,
+}
+
+func init() {
+	searchCmd.Flags().StringVarP(&searchOwner, "owner", "o", "", "Solo repositorios de este usuario u organización")
+	searchCmd.Flags().StringVarP(&searchAccount, "account", "a", "", "Solo librerías visibles por esta cuenta")
+	searchCmd.Flags().StringSliceVarP(&searchTopics, "topic", "t", nil, "Solo librerías con este topic (se puede repetir)")
+	searchCmd.Flags().StringVarP(&searchLanguage, "language", "l", "", "Solo librerías con este lenguaje principal")
+	searchCmd.Flags().BoolVar(&searchSymbol, "symbol", false, "Buscar la declaración de un identificador Go exportado")
+	searchCmd.Flags().IntVarP(&searchLimit, "limit", "n", 20, "Cantidad máxima de resultados")
+	searchCmd.Flags().BoolVar(&searchRefresh, "refresh", false, "Ignorar el cache y consultar de nuevo")
+
+	rootCmd.AddCommand(searchCmd)
+}
+
+// symbolMatch es la declaración de un identificador encontrada en un repositorio
+type symbolMatch struct {
+	Entry catalogEntry
+	Path  string
+	Kind  string
+	Line  string
+}
+
+func runSearch(cmd *cobra.Command, args []string) error {
+	query := strings.TrimSpace(args[0])
+
+	cyan := color.New(color.FgCyan)
+	yellow := color.New(color.FgYellow)
+	gray := color.New(color.FgWhite)
+
+	if searchSymbol && !isExportedIdentifier(query) {
+		color.Red("✗ %q no es un identificador Go exportado (ej: NewClient)", query)
+		return fmt.Errorf("identificador inválido: %s", query)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		color.Red("✗ Error al cargar configuración: %v", err)
+		return err
+	}
+
+	fmt.Println()
+	cyan.Printf("🔎 Buscando %q...\n", query)
+
+	store, err := cache.Open("repos")
+	if err != nil {
+		color.Red("✗ %v", err)
+		return err
+	}
+
+	catalog, errs := loadCatalog(store, cfg, scanOptions{
+		Account:  searchAccount,
+		Owner:    searchOwner,
+		Refresh:  searchRefresh,
+		Language: searchLanguage != "",
+	})
+	catalog = filterCatalog(catalog, searchTopics, searchLanguage)
+	gray.Printf("  %d librerías en el catálogo\n", len(catalog))
+
+	if searchSymbol {
+		matches, symbolErrs := searchSymbols(cfg, catalog, query)
+		errs = append(errs, symbolErrs...)
+		printSymbolMatches(matches, query)
+	} else {
+		printCatalogMatches(rankCatalog(catalog, query), query)
+	}
+
+	if len(errs) > 0 {
+		fmt.Println()
+		yellow.Printf("! %d errores durante la búsqueda:\n", len(errs))
+		for _, err := range errs {
+			gray.Printf("  %v\n", err)
+		}
+	}
+	fmt.Println()
+
+	return nil
+}
+
+// filterCatalog deja las librerías que tienen todos los topics y el lenguaje indicados
+func filterCatalog(catalog []catalogEntry, topics []string, language string) []catalogEntry {
+	var filtered []catalogEntry
+	for _, e := range catalog {
+		if language != "" && !strings.EqualFold(e.Library.Language, language) {
+			continue
+		}
+
+		hasAll := true
+		for _, topic := range topics {
+			found := false
+			for _, t := range e.Library.Topics {
+				if strings.EqualFold(t, topic) {
+					found = true
+					break
+				}
+			}
+			if !found {
+				hasAll = false
+				break
+			}
+		}
+		if hasAll {
+			filtered = append(filtered, e)
+		}
+	}
+	return filtered
+}
+
+// rankCatalog puntúa cada librería contra la consulta y retorna las que
+// coinciden, de mayor a menor relevancia
+func rankCatalog(catalog []catalogEntry, query string) []catalogEntry {
+	var ranked []catalogEntry
+	for _, e := range catalog {
+		if e.Score = matchScore(query, e.Library); e.Score > 0 {
+			ranked = append(ranked, e)
+		}
+	}
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if ranked[i].Score != ranked[j].Score {
+			return ranked[i].Score > ranked[j].Score
+		}
+		return ranked[i].Repo < ranked[j].Repo
+	})
+	return ranked
+}
+
+// matchScore calcula la relevancia de una librería: la consulta completa contra
+// el nombre, y cada palabra contra el nombre, la descripción y los topics
+func matchScore(query string, lib api.Library) int {
+	query = strings.ToLower(query)
+	name := strings.ToLower(lib.Name)
+	description := strings.ToLower(lib.Description)
+
+	score := nameScore(query, name)
+	if strings.Contains(strings.ToLower(lib.Path), query) && score < 50 {
+		score = 50
+	}
+
+	// Palabras cortas ("el", "de", "go") no aportan a la búsqueda
+	var words []string
+	for _, w := range strings.Fields(query) {
+		if len([]rune(w)) >= 3 {
+			words = append(words, w)
+		}
+	}
+
+	for _, w := range words {
+		if len(words) > 1 {
+			score += nameScore(w, name) / 2
+		}
+		if strings.Contains(description, w) {
+			score += 15
+		}
+		for _, t := range lib.Topics {
+			if strings.EqualFold(t, w) {
+				score += 20
+				break
+			}
+		}
+	}
+	return score
+}
+
+// nameScore compara una consulta con un nombre: exacto, prefijo, subcadena,
+// letras en orden o a pocos errores de tipeo. Guiones y guiones bajos se ignoran.
+func nameScore(query, name string) int {
+	q, n := normalizeName(query), normalizeName(name)
+	switch {
+	case q == "":
+		return 0
+	case q == n:
+		return 100
+	case strings.HasPrefix(n, q):
+		return 80
+	case strings.Contains(n, q):
+		return 60
+	case len([]rune(q)) >= 3 && isSubsequence(q, n):
+		return 40
+	case levenshtein(q, n) <= max(1, len([]rune(q))/4):
+		return 35
+	}
+	return 0
+}
+
+// normalizeName quita separadores para comparar "go-retry" con "goretry"
+func normalizeName(s string) string {
+	return strings.NewReplacer("-", "", "_", "", ".", "", " ", "").Replace(s)
+}
+
+// isSubsequence indica si las letras de q aparecen en orden dentro de s
+func isSubsequence(q, s string) bool {
+	rest := []rune(s)
+	for _, r := range q {
+		i := 0
+		for i < len(rest) && rest[i] != r {
+			i++
+		}
+		if i == len(rest) {
+			return false
+		}
+		rest = rest[i+1:]
+	}
+	return true
+}
+
+// levenshtein retorna la distancia de edición entre dos textos
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		cur := make([]int, len(rb)+1)
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev = cur
+	}
+	return prev[len(rb)]
+}
+
+// isExportedIdentifier indica si s es un identificador Go exportado
+func isExportedIdentifier(s string) bool {
+	for i, r := range s {
+		if i == 0 && !unicode.IsUpper(r) {
+			return false
+		}
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_' {
+			return false
+		}
+	}
+	return s != ""
+}
+
+// searchSymbols busca la declaración de un identificador con la búsqueda de
+// código del proveedor de cada cuenta, en paralelo por cuenta
+func searchSymbols(cfg *config.Config, catalog []catalogEntry, symbol string) ([]symbolMatch, []error) {
+	byAccount := make(map[string][]catalogEntry)
+	var accounts []*config.Account
+	for _, e := range catalog {
+		if byAccount[e.Account.Name] == nil {
+			accounts = append(accounts, e.Account)
+		}
+		byAccount[e.Account.Name] = append(byAccount[e.Account.Name], e)
+	}
+
+	providers := newProviderPool()
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	var matches []symbolMatch
+	var errs []error
+
+	for _, acc := range accounts {
+		wg.Add(1)
+		go func(acc *config.Account) {
+			defer wg.Done()
+
+			entries := byAccount[acc.Name]
+			byPath := make(map[string]catalogEntry)
+			paths := make([]string, len(entries))
+			for i, e := range entries {
+				paths[i] = e.Library.Path
+				byPath[e.Library.Path] = e
+			}
+
+			found, err := func() ([]api.CodeMatch, error) {
+				provider, err := providers.get(acc)
+				if err != nil {
+					return nil, err
+				}
+				return provider.SearchCode(symbol, paths)
+			}()
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errs = append(errs, fmt.Errorf("cuenta %s: %w", acc.Name, err))
+				return
+			}
+
+			for _, m := range found {
+				if !strings.HasSuffix(m.Path, ".go") || strings.HasSuffix(m.Path, "_test.go") {
+					continue
+				}
+				line, kind := findDeclaration(m.Fragment, symbol)
+				if line == "" {
+					continue
+				}
+				matches = append(matches, symbolMatch{
+					Entry: byPath[m.RepoPath],
+					Path:  m.Path,
+					Kind:  kind,
+					Line:  line,
+				})
+			}
+		}(acc)
+	}
+	wg.Wait()
+
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].Entry.Repo != matches[j].Entry.Repo {
+			return matches[i].Entry.Repo < matches[j].Entry.Repo
+		}
+		return matches[i].Path < matches[j].Path
+	})
+	return matches, errs
+}
+
+// groupStart detecta el inicio de un bloque const (, var ( o type ( de nivel de paquete
+var groupStart = regexp.MustCompile(`^(const|var|type)\s*\(\s*(//.*)?$`)
+
+// findDeclaration busca en un fragmento de código la declaración de symbol y
+// retorna la línea y su tipo. Reconoce func Name, func (r *T) Name,
+// type/var/const Name y los nombres dentro de bloques agrupados
+// (const ( ... ), var ( ... ), type ( ... )). Los fragmentos que empiezan
+// dentro de un bloque no incluyen su apertura y no se reconocen.
+func findDeclaration(fragment, symbol string) (string, string) {
+	name := regexp.QuoteMeta(symbol)
+	// func Name, func (r *T) Name, type Name, var Name, const Name
+	single := regexp.MustCompile(`^\s*(func\s+(\([^)]*\)\s*)?|type\s+|var\s+|const\s+)` + name + `\b`)
+	// Name, A, Name = ... o Name T dentro de un bloque
+	grouped := regexp.MustCompile(`^\s+(\w+\s*,\s*)*` + name + `\b`)
+
+	// depth cuenta las llaves abiertas dentro del bloque (ej: campos de un
+	// struct) para no confundir campos con declaraciones
+	group, depth := "", 0
+	for _, raw := range strings.Split(fragment, "\n") {
+		line := strings.TrimRight(raw, "\r")
+		switch {
+		case group == "":
+			if m := groupStart.FindStringSubmatch(line); m != nil {
+				group, depth = m[1], 0
+			} else if single.MatchString(line) {
+				return strings.TrimSpace(line), declarationKind(line)
+			}
+			continue
+		case depth == 0 && strings.HasPrefix(strings.TrimSpace(line), ")"):
+			group = ""
+			continue
+		case depth == 0 && grouped.MatchString(line):
+			return strings.TrimSpace(line), group
+		}
+		depth += strings.Count(line, "{") - strings.Count(line, "}")
+	}
+	return "", ""
+}
+
+// declarationKind clasifica una línea de declaración: func, method, type, var o const
+func declarationKind(line string) string {
+	line = strings.TrimSpace(line)
+	switch {
+	case strings.HasPrefix(line, "func ("), strings.HasPrefix(line, "func("):
+		return "method"
+	case strings.HasPrefix(line, "func"):
+		return "func"
+	case strings.HasPrefix(line, "type"):
+		return "type"
+	case strings.HasPrefix(line, "var"):
+		return "var"
+	default:
+		return "const"
+	}
+}
+
+// printCatalogMatches muestra las librerías encontradas en el formato de 'next list'
+func printCatalogMatches(ranked []catalogEntry, query string) {
+	cyan := color.New(color.FgCyan)
+	green := color.New(color.FgGreen)
+	yellow := color.New(color.FgYellow)
+	gray := color.New(color.FgWhite)
+
+	fmt.Println()
+	if len(ranked) == 0 {
+		yellow.Printf("Ninguna librería coincide con %q\n", query)
+		return
+	}
+
+	shown := ranked
+	if searchLimit > 0 && len(shown) > searchLimit {
+		shown = shown[:searchLimit]
+	}
+
+	for _, e := range shown {
+		cyan.Printf("%-30s", e.Library.Name)
+		if e.Library.Visibility == "public" {
+			green.Printf(" [público]")
+		} else {
+			yellow.Printf(" [privado]")
+		}
+		gray.Printf(" %s  cuenta: %s\n", e.Repo, e.Account.Name)
+
+		if e.Library.Description != "" {
+			gray.Printf("  %s\n", e.Library.Description)
+		}
+		if len(e.Library.Topics) > 0 {
+			gray.Printf("  topics: %s\n", strings.Join(e.Library.Topics, ", "))
+		}
+	}
+
+	fmt.Println()
+	if len(shown) < len(ranked) {
+		gray.Printf("%d de %d resultados (use --limit para ver más)\n", len(shown), len(ranked))
+	} else {
+		green.Printf("✔ %d resultados\n", len(ranked))
+	}
+}
+
+// printSymbolMatches muestra las declaraciones encontradas con su package
+func printSymbolMatches(matches []symbolMatch, symbol string) {
+	blue := color.New(color.FgBlue)
+	green := color.New(color.FgGreen)
+	yellow := color.New(color.FgYellow)
+	gray := color.New(color.FgWhite)
+
+	fmt.Println()
+	if len(matches) == 0 {
+		yellow.Printf("No se encontró la declaración de %s\n", symbol)
+		return
+	}
+
+	shown := matches
+	if searchLimit > 0 && len(shown) > searchLimit {
+		shown = shown[:searchLimit]
+	}
+
+	for _, m := range shown {
+		pkg := m.Entry.Repo
+		if dir := path.Dir(m.Path); dir != "." {
+			pkg += "/" + dir
+		}
+		blue.Printf("%-7s", m.Kind)
+		fmt.Printf(" %s.%s\n", pkg, symbol)
+		gray.Printf("        %s  (%s)\n", m.Line, m.Path)
+	}
+
+	fmt.Println()
+	if len(shown) < len(matches) {
+		gray.Printf("%d de %d resultados (use --limit para ver más)\n", len(shown), len(matches))
+	} else {
+		green.Printf("✔ %d declaraciones\n", len(matches))
+	}
+}
@@ -0,0 +1,25 @@
+package next
+
+import "testing"
+
+func TestFindDeclaration(t *testing.T) {
+	for _, tc := range []struct {
+		name, fragment, symbol string
+		line, kind             string
+	}{
+		{"func", "// Retry reintenta\nfunc Retry(fn func() error) error {", "Retry", "func Retry(fn func() error) error {", "func"},
+		{"método", "func (c *Client) Retry(n int) error {", "Retry", "func (c *Client) Retry(n int) error {", "method"},
+		{"type", "type Policy struct {", "Policy", "type Policy struct {", "type"},
+		{"const agrupada", "const (\n\t// DefaultAttempts es el número de intentos\n\tDefaultAttempts = 3\n\tMaxDelay = time.Minute\n)", "MaxDelay", "MaxDelay = time.Minute", "const"},
+		{"var agrupada con lista", "var (\n\tErrA, ErrTimeout = errors.New(\"a\"), errors.New(\"t\")\n)", "ErrTimeout", "ErrA, ErrTimeout = errors.New(\"a\"), errors.New(\"t\")", "var"},
+		{"type agrupado", "type (\n\tBackoff func(int) time.Duration\n\tPolicy struct {\n\t\tBackoff Backoff\n\t}\n)", "Policy", "Policy struct {", "type"},
+		{"campo de struct", "type (\n\tPolicy struct {\n\t\tAttempts int\n\t}\n)", "Attempts", "", ""},
+		{"fuera del bloque", "const (\n\tA = 1\n)\n\tAttempts := 3", "Attempts", "", ""},
+		{"uso", "\treturn Retry(fn)", "Retry", "", ""},
+	} {
+		line, kind := findDeclaration(tc.fragment, tc.symbol)
+		if line != tc.line || kind != tc.kind {
+			t.Errorf("%s: findDeclaration = %q, %q; se esperaba %q, %q", tc.name, line, kind, tc.line, tc.kind)
+		}
+	}
+}
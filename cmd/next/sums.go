@@ -0,0 +1,414 @@
+package next
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/reitmas32/next/internal/config"
+	"github.com/reitmas32/next/internal/modproxy"
+	"github.com/reitmas32/next/internal/sumdb"
+	"github.com/spf13/cobra"
+)
+
+var (
+	sumsLedger   string
+	sumsStrict   bool
+	trustComment string
+	trustList    bool
+)
+
+var recordSumsCmd = &cobra.Command{
+	Use:   "record-sums <módulo>[@versión]...",
+	Short: "Registra los hashes h1: de versiones privadas en el ledger firmado",
+	Long: `Calcula los hashes h1: (los mismos de go.sum) de versiones de módulos
+privados y los agrega al ledger firmado. Sin @versión se registran todos los
+tags del módulo. 'next create-version' registra automáticamente cada versión
+nueva.
+
+El ledger es un archivo append-only: cada registro está firmado con su llave
+ed25519 (~/.next/sumdb/signing.key) e incluye el hash del registro anterior.
+Una versión ya registrada con otro hash no se sobrescribe: indica que el tag
+fue movido.
+
+Por defecto el ledger está en ~/.next/sumdb/ledger.jsonl. Para compartirlo,
+use --ledger (o NEXT_SUMS_LEDGER) con un archivo dentro de un repositorio.
+Solo se leen ledgers cuyos registros están firmados por llaves confiables y
+solo se firma con una llave confiable (ver 'next trust-key').
+
+Ejemplos:
+  next record-sums github.com/mi-empresa/core-lib
+  next record-sums github.com/mi-empresa/core-lib@v1.2.0 --ledger ./sums/ledger.jsonl`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runRecordSums,
+}
+
+var verifySumsCmd = &cobra.Command{
+	Use:   "verify-sums",
+	Short: "Verifica go.sum de las dependencias privadas contra el ledger firmado",
+	Long: `Compara los hashes de go.sum (y go.work.sum en un workspace) de las
+dependencias privadas con los registrados en el ledger. Como GOPRIVATE
+desactiva sum.golang.org, esto detecta tags movidos o contenido alterado.
+
+Solo se aceptan ledgers cuyos registros están firmados por llaves confiables
+(~/.next/trusted_keys, ver 'next trust-key'). Termina con error si algún hash no coincide (o, con --strict, si
+alguna versión no está registrada).
+
+Ejemplos:
+  next verify-sums
+  next verify-sums --strict --ledger ./sums/ledger.jsonl`,
+	RunE: runVerifySums,
+}
+
+var trustKeyCmd = &cobra.Command{
+	Use:   "trust-key [llave]",
+	Short: "Agrega una llave de firma a las llaves confiables del ledger",
+	Long: `Agrega una llave pública ed25519 ("ed25519:<base64>") a las llaves
+confiables, en ~/.next/trusted_keys. Sin argumento se agrega la llave propia
+(~/.next/sumdb/signing.key), necesaria para firmar con record-sums y
+create-version.
+
+Las llaves confiables son locales de cada usuario y están fuera del
+directorio de cualquier ledger: confiar en la llave de un compañero es una
+decisión explícita, nunca algo que un ledger compartido pueda declarar.
+
+Ejemplos:
+  next trust-key
+  next trust-key ed25519:3q2+7w... --comment "ana (CI)"
+  next trust-key --list`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runTrustKey,
+}
+
+func init() {
+	recordSumsCmd.Flags().StringVar(&sumsLedger, "ledger", "", "Archivo del ledger (default: $NEXT_SUMS_LEDGER o ~/.next/sumdb/ledger.jsonl)")
+	verifySumsCmd.Flags().StringVar(&sumsLedger, "ledger", "", "Archivo del ledger (default: $NEXT_SUMS_LEDGER o ~/.next/sumdb/ledger.jsonl)")
+	verifySumsCmd.Flags().BoolVar(&sumsStrict, "strict", false, "Fallar también si una versión privada no está registrada")
+
+	trustKeyCmd.Flags().StringVar(&trustComment, "comment", "", "Comentario para identificar la llave (default: hostname para la llave propia)")
+	trustKeyCmd.Flags().BoolVar(&trustList, "list", false, "Listar las llaves confiables")
+
+	rootCmd.AddCommand(recordSumsCmd)
+	rootCmd.AddCommand(verifySumsCmd)
+	rootCmd.AddCommand(trustKeyCmd)
+}
+
+// ledgerPath resuelve la ruta del ledger: flag, NEXT_SUMS_LEDGER o el default
+func ledgerPath(flag string) (string, error) {
+	if flag != "" {
+		return flag, nil
+	}
+	if env := os.Getenv("NEXT_SUMS_LEDGER"); env != "" {
+		return env, nil
+	}
+	return sumdb.DefaultLedgerPath()
+}
+
+// openTrustedLedger abre el ledger validando las firmas con las llaves confiables
+func openTrustedLedger(path string) (*sumdb.Ledger, map[string]bool, error) {
+	trusted, err := sumdb.TrustedKeys()
+	if err != nil {
+		return nil, nil, fmt.Errorf("error al leer las llaves confiables: %w", err)
+	}
+
+	ledger, err := sumdb.OpenLedger(path, trusted)
+	if err != nil {
+		return nil, nil, err
+	}
+	return ledger, trusted, nil
+}
+
+func runRecordSums(cmd *cobra.Command, args []string) error {
+	cyan := color.New(color.FgCyan)
+	green := color.New(color.FgGreen)
+	gray := color.New(color.FgWhite)
+
+	cfg, err := config.Load()
+	if err != nil {
+		color.Red("✗ Error al cargar configuración: %v", err)
+		return err
+	}
+
+	path, err := ledgerPath(sumsLedger)
+	if err != nil {
+		return err
+	}
+
+	ledger, trusted, err := openTrustedLedger(path)
+	if err != nil {
+		color.Red("✗ %v", err)
+		return err
+	}
+
+	key, err := sumdb.LoadOrCreateKey()
+	if err != nil {
+		color.Red("✗ %v", err)
+		return err
+	}
+
+	publicKey := sumdb.PublicKeyString(key.Public().(ed25519.PublicKey))
+	if !trusted[publicKey] {
+		err := fmt.Errorf("la llave de firma no es confiable")
+		color.Red("✗ %v: %s", err, publicKey)
+		gray.Println("  Revise que sea su llave y ejecute: next trust-key")
+		return err
+	}
+
+	fmt.Println()
+	cyan.Printf("📒 Ledger: %s\n", path)
+	fmt.Println()
+
+	recorded, failed := 0, 0
+	for _, arg := range args {
+		module, version, _ := strings.Cut(arg, "@")
+		cyan.Printf("• %s\n", module)
+
+		src, _, err := moduleSource(cfg, module)
+		if err == nil {
+			err = src.Sync()
+		}
+		if err != nil {
+			color.Red("  ✗ %v", err)
+			failed++
+			continue
+		}
+
+		versions := []string{version}
+		if version == "" {
+			if versions, err = src.Versions(); err != nil {
+				color.Red("  ✗ %v", err)
+				failed++
+				continue
+			}
+		}
+
+		for _, v := range versions {
+			added, hash, err := recordSourceSums(ledger, key, src, v)
+			switch {
+			case err != nil:
+				color.Red("  ✗ %s: %v", v, err)
+				failed++
+			case added:
+				green.Printf("  ✔ %s %s\n", v, hash)
+				recorded++
+			default:
+				gray.Printf("  = %s %s (ya registrado)\n", v, hash)
+			}
+		}
+	}
+
+	fmt.Println()
+	green.Printf("✔ %d versiones registradas\n", recorded)
+	gray.Printf("  Llave: %s\n", publicKey)
+	fmt.Println()
+
+	if failed > 0 {
+		return fmt.Errorf("%d versiones no se pudieron registrar", failed)
+	}
+	return nil
+}
+
+// recordSourceSums calcula los hashes de una versión y los agrega al ledger
+func recordSourceSums(ledger *sumdb.Ledger, key ed25519.PrivateKey, src *modproxy.Source, version string) (bool, string, error) {
+	var buf bytes.Buffer
+	if err := src.Zip(&buf, version); err != nil {
+		return false, "", err
+	}
+
+	hash, err := sumdb.HashZip(buf.Bytes())
+	if err != nil {
+		return false, "", err
+	}
+
+	mod, err := src.GoMod(version)
+	if err != nil {
+		return false, "", err
+	}
+
+	modHash, err := sumdb.HashGoMod(mod)
+	if err != nil {
+		return false, "", err
+	}
+
+	added, err := ledger.Append(src.Module, version, hash, modHash, key)
+	return added, hash, err
+}
+
+func runVerifySums(cmd *cobra.Command, args []string) error {
+	cyan := color.New(color.FgCyan)
+	green := color.New(color.FgGreen)
+	yellow := color.New(color.FgYellow)
+	gray := color.New(color.FgWhite)
+
+	project, err := loadProject()
+	if err != nil {
+		color.Red("✗ %v", err)
+		return err
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		color.Red("✗ Error al cargar configuración: %v", err)
+		return err
+	}
+
+	path, err := ledgerPath(sumsLedger)
+	if err != nil {
+		return err
+	}
+
+	ledger, trusted, err := openTrustedLedger(path)
+	if err != nil {
+		color.Red("✗ %v", err)
+		return err
+	}
+
+	fmt.Println()
+	cyan.Printf("🔏 Verificando go.sum contra %s\n", path)
+	if len(trusted) == 0 {
+		yellow.Println("! No hay llaves confiables: ningún registro se puede verificar (ver 'next trust-key')")
+	}
+	fmt.Println()
+
+	private := make(map[string]bool)
+	ok, mismatched, missing := 0, 0, 0
+
+	for _, file := range project.SumFiles() {
+		sums, err := sumdb.ParseGoSum(file)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			color.Red("✗ Error al leer %s: %v", file, err)
+			return err
+		}
+
+		for _, sum := range sums {
+			isPrivate, seen := private[sum.Module]
+			if !seen {
+				_, _, accErr := accountForModule(cfg, sum.Module)
+				isPrivate = accErr == nil
+				private[sum.Module] = isPrivate
+			}
+			if !isPrivate {
+				continue
+			}
+
+			label := sum.Module + "@" + sum.Version
+			if sum.GoMod {
+				label += "/go.mod"
+			}
+
+			records := ledger.Lookup(sum.Module, sum.Version)
+			if len(records) == 0 {
+				yellow.Printf("  ? %s (no registrado)\n", label)
+				missing++
+				continue
+			}
+
+			expected := records[0].Hash
+			if sum.GoMod {
+				expected = records[0].GoModHash
+			}
+
+			if sum.Hash != expected {
+				color.Red("  ✗ %s", label)
+				color.Red("      go.sum: %s", sum.Hash)
+				color.Red("      ledger: %s", expected)
+				mismatched++
+				continue
+			}
+
+			gray.Printf("  ✔ %s\n", label)
+			ok++
+		}
+	}
+
+	fmt.Println()
+	switch {
+	case mismatched > 0:
+		color.Red("✗ %d hashes no coinciden con el ledger (%d correctos, %d sin registrar)", mismatched, ok, missing)
+		fmt.Println()
+		return fmt.Errorf("%d hashes no coinciden", mismatched)
+	case missing > 0 && sumsStrict:
+		color.Red("✗ %d versiones privadas sin registrar en el ledger", missing)
+		fmt.Println()
+		return fmt.Errorf("%d versiones sin registrar", missing)
+	case missing > 0:
+		yellow.Printf("! %d correctos, %d sin registrar (use 'next record-sums' para registrarlos)\n", ok, missing)
+	default:
+		green.Printf("✔ %d hashes verificados\n", ok)
+	}
+	fmt.Println()
+
+	return nil
+}
+
+func runTrustKey(cmd *cobra.Command, args []string) error {
+	green := color.New(color.FgGreen)
+	gray := color.New(color.FgWhite)
+
+	path, err := sumdb.TrustedKeysPath()
+	if err != nil {
+		return err
+	}
+
+	if trustList {
+		trusted, err := sumdb.TrustedKeys()
+		if err != nil {
+			color.Red("✗ %v", err)
+			return err
+		}
+
+		fmt.Println()
+		if len(trusted) == 0 {
+			color.Yellow("No hay llaves confiables en %s", path)
+		}
+		keys := make([]string, 0, len(trusted))
+		for key := range trusted {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			fmt.Println(key)
+		}
+		fmt.Println()
+		return nil
+	}
+
+	comment := trustComment
+	var publicKey string
+	if len(args) == 1 {
+		publicKey = args[0]
+	} else {
+		key, err := sumdb.LoadOrCreateKey()
+		if err != nil {
+			color.Red("✗ %v", err)
+			return err
+		}
+		publicKey = sumdb.PublicKeyString(key.Public().(ed25519.PublicKey))
+		if comment == "" {
+			comment, _ = os.Hostname()
+		}
+	}
+
+	added, err := sumdb.TrustKey(publicKey, comment)
+	if err != nil {
+		color.Red("✗ %v", err)
+		return err
+	}
+
+	fmt.Println()
+	if added {
+		green.Printf("✔ Llave confiable agregada: %s\n", publicKey)
+	} else {
+		gray.Printf("= La llave ya era confiable: %s\n", publicKey)
+	}
+	gray.Printf("  %s\n", path)
+	fmt.Println()
+	return nil
+}
@@ -0,0 +1,374 @@
+package next
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/reitmas32/next/internal/config"
+	"github.com/reitmas32/next/internal/gomod"
+	"github.com/spf13/cobra"
+)
+
+var (
+	upgradeAll    bool
+	upgradePatch  bool
+	upgradeMinor  bool
+	upgradeDryRun bool
+)
+
+var upgradeCmd = &cobra.Command{
+	Use:   "upgrade [módulo[@versión]...]",
+	Short: "Actualiza dependencias privadas a versiones más recientes",
+	Long: `Actualiza los require de dependencias privadas del go.mod del directorio
+actual, ejecuta 'go mod tidy' con las credenciales configuradas (como
+'next exec') y muestra los cambios en los require.
+
+Las versiones se eligen entre los tags que la cuenta de cada dependencia puede
+ver, sin cambiar el module path (las versiones /vN nuevas requieren cambiar
+los imports) y sin prereleases, salvo que se pidan explícitamente:
+  --patch   solo versiones con el mismo X.Y (vX.Y.*)
+  --minor   solo versiones con el mismo X (vX.*)
+
+Sin argumentos ni --all se muestran las actualizaciones disponibles y se
+pregunta cuáles aplicar. Si 'go mod tidy' falla, go.mod y go.sum se restauran.
+
+Ejemplos:
+  next upgrade
+  next upgrade --all --patch
+  next upgrade github.com/mi-empresa/core-lib
+  next upgrade github.com/mi-empresa/core-lib@v1.4.2`,
+	RunE: runUpgrade,
+}
+
+func init() {
+	upgradeCmd.Flags().BoolVar(&upgradeAll, "all", false, "Actualizar todas las dependencias privadas")
+	upgradeCmd.Flags().BoolVar(&upgradePatch, "patch", false, "Solo actualizaciones patch (mismo vX.Y)")
+	upgradeCmd.Flags().BoolVar(&upgradeMinor, "minor", false, "Solo actualizaciones minor y patch (mismo vX)")
+	upgradeCmd.Flags().BoolVar(&upgradeDryRun, "dry-run", false, "Mostrar las actualizaciones sin aplicarlas")
+
+	rootCmd.AddCommand(upgradeCmd)
+}
+
+// plannedUpgrade es un cambio de versión de un require
+type plannedUpgrade struct {
+	Module string
+	From   string
+	To     string
+}
+
+func runUpgrade(cmd *cobra.Command, args []string) error {
+	cyan := color.New(color.FgCyan)
+	green := color.New(color.FgGreen)
+	yellow := color.New(color.FgYellow)
+	gray := color.New(color.FgWhite)
+
+	if upgradePatch && upgradeMinor {
+		color.Red("✗ Use solo uno de --patch o --minor")
+		return fmt.Errorf("flags incompatibles")
+	}
+	if upgradeAll && len(args) > 0 {
+		color.Red("✗ Use --all o una lista de módulos, no ambos")
+		return fmt.Errorf("flags incompatibles")
+	}
+
+	if _, err := os.Stat("go.mod"); os.IsNotExist(err) {
+		color.Red("✗ No se encontró go.mod en el directorio actual")
+		return err
+	}
+
+	modFile, err := gomod.Parse("go.mod")
+	if err != nil {
+		color.Red("✗ Error al leer go.mod: %v", err)
+		return err
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		color.Red("✗ Error al cargar configuración: %v", err)
+		return err
+	}
+
+	// Módulos a considerar: los indicados o todas las dependencias privadas
+	targets := make(map[string]string)
+	var order []string
+	if len(args) > 0 {
+		for _, arg := range args {
+			module, version, _ := strings.Cut(arg, "@")
+			if _, ok := modFile.FindRequire(module); !ok {
+				color.Red("✗ %s no está en los require de go.mod", module)
+				return fmt.Errorf("módulo no requerido: %s", module)
+			}
+			targets[module] = version
+			order = append(order, module)
+		}
+	} else {
+		for _, r := range modFile.Require {
+			if _, _, err := accountForModule(cfg, r.Path); err == nil {
+				targets[r.Path] = ""
+				order = append(order, r.Path)
+			}
+		}
+	}
+
+	if len(order) == 0 {
+		yellow.Println("No se detectaron dependencias privadas en go.mod")
+		return nil
+	}
+
+	fmt.Println()
+	cyan.Printf("🔎 Consultando versiones de %d dependencias privadas...\n", len(order))
+
+	providers := newProviderPool()
+	var planned []plannedUpgrade
+	for _, module := range order {
+		req, _ := modFile.FindRequire(module)
+
+		_, _, versions, err := listModuleVersions(cfg, providers, module)
+		if err != nil {
+			color.Red("✗ %s: %v", module, err)
+			return err
+		}
+
+		to, err := chooseUpgrade(module, req.Version, targets[module], versions)
+		if err != nil {
+			color.Red("✗ %s: %v", module, err)
+			return err
+		}
+		if to == "" || to == req.Version {
+			gray.Printf("  = %s %s (sin actualizaciones)\n", module, req.Version)
+			continue
+		}
+		planned = append(planned, plannedUpgrade{Module: module, From: req.Version, To: to})
+	}
+
+	if len(planned) == 0 {
+		fmt.Println()
+		green.Println("✔ Todas las dependencias están al día")
+		fmt.Println()
+		return nil
+	}
+
+	fmt.Println()
+	for i, u := range planned {
+		fmt.Printf("  %d) %s %s → ", i+1, u.Module, u.From)
+		green.Printf("%s", u.To)
+		gray.Printf(" (%s)\n", updateLevel(u.From, u.To))
+	}
+	fmt.Println()
+
+	if upgradeDryRun {
+		yellow.Println("! --dry-run: no se modificó go.mod")
+		fmt.Println()
+		return nil
+	}
+
+	// Modo interactivo: sin módulos ni --all se pregunta qué aplicar
+	if len(args) == 0 && !upgradeAll {
+		planned = promptUpgrades(planned)
+		if len(planned) == 0 {
+			yellow.Println("No se seleccionó ninguna actualización")
+			return nil
+		}
+	}
+
+	return applyUpgrades(cfg, planned)
+}
+
+// chooseUpgrade elige la versión destino de un módulo. Con una versión
+// explícita valida que exista y corresponda al module path; sin ella elige la
+// release más alta permitida por --patch/--minor.
+func chooseUpgrade(module, current, requested string, versions []string) (string, error) {
+	if requested != "" {
+		if !gomod.IsValidVersion(requested) {
+			return "", fmt.Errorf("versión inválida: %s", requested)
+		}
+		if !sameModuleMajor(module, requested) {
+			return "", fmt.Errorf("%s no corresponde al module path (las versiones v%d requieren el sufijo /v%d)", requested, gomod.Major(requested), gomod.Major(requested))
+		}
+		if !containsVersion(versions, requested) {
+			return "", fmt.Errorf("la versión %s no existe o la cuenta no tiene acceso a ella", requested)
+		}
+		return requested, nil
+	}
+
+	from := versionParts(current)
+	var candidates []string
+	for _, v := range versions {
+		if !sameModuleMajor(module, v) || gomod.IsPrerelease(v) || gomod.CompareVersions(v, current) <= 0 {
+			continue
+		}
+		parts := versionParts(v)
+		if (upgradePatch || upgradeMinor) && parts[0] != from[0] {
+			continue
+		}
+		if upgradePatch && parts[1] != from[1] {
+			continue
+		}
+		candidates = append(candidates, v)
+	}
+	return gomod.LatestVersion(candidates), nil
+}
+
+// promptUpgrades pregunta qué actualizaciones aplicar ("1,3", "a" para todas)
+func promptUpgrades(planned []plannedUpgrade) []plannedUpgrade {
+	fmt.Print("¿Cuáles aplicar? (ej: 1,3; 'a' para todas; Enter para ninguna): ")
+
+	reader := bufio.NewReader(os.Stdin)
+	response, _ := reader.ReadString('\n')
+	response = strings.TrimSpace(strings.ToLower(response))
+
+	switch response {
+	case "":
+		return nil
+	case "a", "all", "todas":
+		return planned
+	}
+
+	var selected []plannedUpgrade
+	seen := make(map[int]bool)
+	for _, field := range strings.FieldsFunc(response, func(r rune) bool { return r == ',' || r == ' ' }) {
+		n, err := strconv.Atoi(field)
+		if err != nil || n < 1 || n > len(planned) || seen[n] {
+			continue
+		}
+		seen[n] = true
+		selected = append(selected, planned[n-1])
+	}
+	return selected
+}
+
+// applyUpgrades actualiza los require, ejecuta go mod tidy con credenciales y
+// restaura go.mod/go.sum si algo falla
+func applyUpgrades(cfg *config.Config, planned []plannedUpgrade) error {
+	cyan := color.New(color.FgCyan)
+	green := color.New(color.FgGreen)
+	yellow := color.New(color.FgYellow)
+
+	before, err := gomod.Parse("go.mod")
+	if err != nil {
+		return err
+	}
+
+	backup, err := backupFiles("go.mod", "go.sum")
+	if err != nil {
+		color.Red("✗ Error al respaldar go.mod/go.sum: %v", err)
+		return err
+	}
+
+	fail := func(err error) error {
+		color.Red("✗ %v", err)
+		if restoreErr := restoreFiles(backup); restoreErr != nil {
+			color.Red("✗ No se pudieron restaurar go.mod/go.sum: %v", restoreErr)
+		} else {
+			yellow.Println("! go.mod y go.sum restaurados")
+		}
+		fmt.Println()
+		return err
+	}
+
+	editArgs := []string{"mod", "edit"}
+	for _, u := range planned {
+		editArgs = append(editArgs, "-require="+u.Module+"@"+u.To)
+	}
+	if output, err := exec.Command("go", editArgs...).CombinedOutput(); err != nil {
+		return fail(fmt.Errorf("error al editar go.mod: %s", strings.TrimSpace(string(output))))
+	}
+
+	env, err := execEnv(cfg, "module")
+	if err != nil {
+		return fail(err)
+	}
+
+	cyan.Println("📦 Ejecutando go mod tidy...")
+	if code := runWithEnv([]string{"go", "mod", "tidy"}, env); code != 0 {
+		return fail(fmt.Errorf("go mod tidy terminó con código %d", code))
+	}
+
+	after, err := gomod.Parse("go.mod")
+	if err != nil {
+		return fail(err)
+	}
+
+	fmt.Println()
+	printRequireDiff(before, after)
+	fmt.Println()
+	green.Printf("✔ %d dependencias actualizadas\n", len(planned))
+	fmt.Println()
+
+	return nil
+}
+
+// printRequireDiff muestra los require agregados, eliminados o cambiados
+func printRequireDiff(before, after *gomod.File) {
+	green := color.New(color.FgGreen)
+	red := color.New(color.FgRed)
+
+	changed := false
+	for _, r := range before.Require {
+		next, ok := after.FindRequire(r.Path)
+		switch {
+		case !ok:
+			red.Printf("- %s %s\n", r.Path, r.Version)
+			changed = true
+		case next.Version != r.Version:
+			red.Printf("- %s %s\n", r.Path, r.Version)
+			green.Printf("+ %s %s\n", next.Path, next.Version)
+			changed = true
+		}
+	}
+	for _, r := range after.Require {
+		if _, ok := before.FindRequire(r.Path); !ok {
+			green.Printf("+ %s %s\n", r.Path, r.Version)
+			changed = true
+		}
+	}
+
+	if !changed {
+		color.White("  (sin cambios en los require)")
+	}
+}
+
+// fileBackup es el contenido original de un archivo (Exists=false si no existía)
+type fileBackup struct {
+	Path   string
+	Data   []byte
+	Exists bool
+}
+
+// backupFiles guarda el contenido de los archivos para poder restaurarlos
+func backupFiles(paths ...string) ([]fileBackup, error) {
+	var backups []fileBackup
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		switch {
+		case os.IsNotExist(err):
+			backups = append(backups, fileBackup{Path: path})
+		case err != nil:
+			return nil, err
+		default:
+			backups = append(backups, fileBackup{Path: path, Data: data, Exists: true})
+		}
+	}
+	return backups, nil
+}
+
+// restoreFiles deja los archivos como estaban al respaldarlos
+func restoreFiles(backups []fileBackup) error {
+	for _, b := range backups {
+		if !b.Exists {
+			if err := os.Remove(b.Path); err != nil && !os.IsNotExist(err) {
+				return err
+			}
+			continue
+		}
+		if err := os.WriteFile(b.Path, b.Data, 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+package next
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/reitmas32/next/internal/config"
+	"github.com/reitmas32/next/internal/vanity"
+)
+
+// moduleLocation es la ubicación real de un módulo: para import paths vanity
+// (ej: go.company.dev/libs/foo) es el host y repositorio de la meta tag go-import
+type moduleLocation struct {
+	Domain string
+	Owner  string
+	// Repo es host/path del repositorio real; vacío si el módulo no es vanity
+	Repo string
+	// Prefix es el import path de la raíz del repositorio (solo vanity)
+	Prefix string
+}
+
+// accountForModule busca la cuenta de un módulo con GetAccountForModule y, si
+// no hay coincidencia, resuelve el import path vanity y busca la cuenta del
+// repositorio real
+func accountForModule(cfg *config.Config, module string) (*config.Account, moduleLocation, error) {
+	return locateModule(cfg, module, nil, false)
+}
+
+// locateModule es accountForModule con una cuenta fija (account no nil, ej:
+// --account) y, con offline, resolviendo los paths vanity solo desde el cache
+func locateModule(cfg *config.Config, module string, account *config.Account, offline bool) (*config.Account, moduleLocation, error) {
+	location := moduleLocation{Domain: extractDomain(module), Owner: extractOwner(module)}
+
+	var err error
+	if account == nil {
+		account, err = cfg.GetAccountForModule(module)
+		if err == nil || !shouldResolveVanity(cfg, module) {
+			return account, location, err
+		}
+	} else if !shouldResolveVanity(cfg, module) {
+		return account, location, nil
+	}
+
+	imp, resolveErr := resolveVanity(module, offline)
+	if resolveErr != nil || imp == nil || imp.Location() == "" {
+		if account != nil {
+			return account, location, nil
+		}
+		return nil, location, err
+	}
+
+	repo := imp.Location()
+	if account == nil {
+		account, err = cfg.GetAccountForModule(repo)
+		if err != nil {
+			return nil, location, err
+		}
+	}
+
+	return account, moduleLocation{Domain: extractDomain(repo), Owner: extractOwner(repo), Repo: repo, Prefix: imp.Prefix}, nil
+}
+
+// resolveVanity resuelve un import path vanity; en modo offline solo usa el cache
+func resolveVanity(module string, offline bool) (*vanity.Import, error) {
+	if !offline {
+		return vanity.Resolve(module)
+	}
+	imp, ok := vanity.Lookup(module)
+	if !ok {
+		return nil, fmt.Errorf("%s no está en el cache de paths vanity (modo offline)", module)
+	}
+	return imp, nil
+}
+
+// prefetchVanity resuelve en paralelo los paths vanity de los módulos sin
+// cuenta, para que accountForModule los encuentre en el cache en lugar de
+// esperar cada consulta una por una
+func prefetchVanity(cfg *config.Config, modules []string) {
+	var pending []string
+	for _, module := range modules {
+		if _, err := cfg.GetAccountForModule(module); err != nil && shouldResolveVanity(cfg, module) {
+			pending = append(pending, module)
+		}
+	}
+
+	if len(pending) > 1 {
+		vanity.ResolveAll(pending)
+	}
+}
+
+// shouldResolveVanity evita consultas de red para dominios de cuentas
+// configuradas, hosts de código conocidos y hosts vanity públicos
+func shouldResolveVanity(cfg *config.Config, module string) bool {
+	if !vanity.NeedsResolve(module) {
+		return false
+	}
+
+	domain := extractDomain(module)
+	for _, acc := range cfg.Accounts {
+		accountDomain := strings.TrimPrefix(strings.TrimPrefix(acc.Domain, "https://"), "http://")
+		if extractDomain(accountDomain) == domain {
+			return false
+		}
+	}
+	return true
+}
@@ -1,30 +1,63 @@
 package next
 
 import (
+	"errors"
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/fatih/color"
 	"github.com/reitmas32/next/internal/api"
 	"github.com/reitmas32/next/internal/config"
+	"github.com/reitmas32/next/internal/gomod"
 	"github.com/spf13/cobra"
 )
 
-var versionsAccount string
+var (
+	versionsAccounts accountSelection
+	versionsCache    apiCacheOptions
+	versionsModule   bool
+)
 
 var versionsCmd = &cobra.Command{
-	Use:   "versions <library>",
+	Use:   "versions <librería | módulo>",
 	Short: "Lista todas las versiones (tags) de una librería",
 	Long: `Lista todas las versiones (tags) de una librería.
-Los tags se muestran ordenados por fecha, de más reciente a más antiguo.
+Los tags se muestran de la versión más reciente a la más antigua (semver);
+los tags que no son versiones van al final.
+
+La librería puede ser el path del repositorio en la cuenta (org/lib) o un
+module path completo (github.com/org/lib/sub/v2). El argumento se trata como
+module path si empieza con el dominio de una cuenta configurada o si se usa
+--module (necesario para paths vanity); así un grupo de GitLab con puntos
+(mi.grupo/lib) sigue siendo un path de repositorio. Con un module path la
+cuenta se elige como en 'next check' (se puede forzar con --account), se ubica
+el repositorio del módulo y solo se muestran sus versiones: los tags con el
+prefijo de su subdirectorio (sub/v1.2.0) y de su versión mayor.
+
+Las consultas usan el mismo cache que 'next list' (--offline, --refresh,
+--cache-ttl). Con --offline los paths vanity y los subgrupos de GitLab se
+resuelven solo con lo que ya está en el cache.
+
+Con --all-accounts (o --domain) se busca la librería en todas las cuentas en
+paralelo y se muestran sus versiones una vez por módulo, con las cuentas que
+la ven.
 
 Ejemplo:
-  next versions fundation --account gitlab-main`,
+  next versions fundation --account gitlab-main
+  next versions fundation --account gitlab-main --offline
+  next versions github.com/mi-empresa/core-lib/v2
+  next versions gitlab.com/grupo/monorepo/sub
+  next versions go.mi-empresa.dev/core --module
+  next versions mi-empresa/core-lib --all-accounts`,
 	Args: cobra.ExactArgs(1),
 	RunE: runVersions,
 }
 
 func init() {
-	versionsCmd.Flags().StringVarP(&versionsAccount, "account", "a", "", "Nombre de la cuenta a usar")
+	addAccountSelectionFlags(versionsCmd, &versionsAccounts)
+	addAPICacheFlags(versionsCmd, &versionsCache)
+	versionsCmd.Flags().BoolVar(&versionsModule, "module", false, "Tratar el argumento como module path (ej: paths vanity)")
 }
 
 func runVersions(cmd *cobra.Command, args []string) error {
@@ -37,15 +70,35 @@ func runVersions(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	// Obtener cuenta
-	account, err := cfg.GetAccount(versionsAccount)
-	if err != nil {
-		color.Red("✗ %v", err)
-		return err
+	// Un module path se resuelve a su repositorio
+	var repo *moduleRepo
+	if versionsModule || hasAccountDomain(cfg, library) {
+		repo, err = versionsModuleRepository(cfg, library)
+		if err != nil {
+			color.Red("✗ %v", err)
+			return err
+		}
+		library = repo.RepoPath()
+	}
+
+	if versionsAccounts.multiple() {
+		return runVersionsAllAccounts(cfg, library, repo)
+	}
+
+	// Obtener cuenta: la indicada o la que corresponde al módulo
+	var account *config.Account
+	if repo != nil {
+		account = repo.Account
+	} else {
+		account, err = cfg.GetAccount(versionsAccounts.Account)
+		if err != nil {
+			color.Red("✗ %v", err)
+			return err
+		}
 	}
 
 	// Crear cliente del proveedor
-	provider, err := api.NewProvider(account.Provider, account.Domain, account.Token)
+	provider, err := newCachedProvider(cfg, account, versionsCache)
 	if err != nil {
 		color.Red("✗ Error al crear cliente: %v", err)
 		return err
@@ -57,6 +110,12 @@ func runVersions(cmd *cobra.Command, args []string) error {
 		color.Red("✗ Error al obtener versiones: %v", err)
 		return err
 	}
+	if repo != nil {
+		versions = moduleTagVersions(repo, versions)
+		library = repo.Module
+	} else {
+		sortVersionsDesc(versions)
+	}
 
 	if len(versions) == 0 {
 		color.Yellow("No se encontraron versiones para '%s'", library)
@@ -64,10 +123,15 @@ func runVersions(cmd *cobra.Command, args []string) error {
 	}
 
 	// Mostrar versiones
+	cyan := color.New(color.FgCyan)
 	blue := color.New(color.FgBlue)
 	gray := color.New(color.FgWhite)
 
 	fmt.Println()
+	if repo != nil {
+		cyan.Print(repo.Module)
+		gray.Printf("  (repositorio %s, cuenta %s)\n", repo.Repo, account.Name)
+	}
 	for _, v := range versions {
 		blue.Printf("%-12s", v.Name)
 		gray.Printf(" %s\n", v.Date)
@@ -76,3 +140,184 @@ func runVersions(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+// hasAccountDomain indica si el primer elemento del argumento es el dominio
+// de una cuenta configurada
+func hasAccountDomain(cfg *config.Config, library string) bool {
+	first, _, _ := strings.Cut(library, "/")
+	for _, acc := range cfg.Accounts {
+		if normalizeAccountDomain(acc.Domain) == first {
+			return true
+		}
+	}
+	return false
+}
+
+// versionsModuleRepository ubica el repositorio de un module path con la
+// cuenta de --account (si se indicó). En modo offline los subgrupos de GitLab
+// se prueban contra el cache de la API en lugar de git ls-remote.
+func versionsModuleRepository(cfg *config.Config, module string) (*moduleRepo, error) {
+	lookup := repoLookup{Offline: versionsCache.Offline}
+
+	if versionsAccounts.Account != "" {
+		account, err := cfg.GetAccount(versionsAccounts.Account)
+		if err != nil {
+			return nil, err
+		}
+		lookup.Account = account
+	}
+
+	if versionsCache.Offline {
+		lookup.Exists = func(account *config.Account, repo string) bool {
+			provider, err := newCachedProvider(cfg, account, versionsCache)
+			if err != nil {
+				return false
+			}
+			_, repoPath, _ := strings.Cut(repo, "/")
+			_, err = provider.ListVersions(repoPath)
+			return err == nil
+		}
+	}
+
+	return lookupModuleRepository(cfg, module, lookup)
+}
+
+// accountVersions son las versiones de una librería vistas por un grupo de cuentas
+type accountVersions struct {
+	Module   string
+	Versions []api.Version
+	Accounts []*config.Account
+}
+
+// runVersionsAllAccounts busca las versiones de una librería en varias
+// cuentas en paralelo. Las cuentas que no ven la librería no son un error.
+// Con un module path (repo no nil) solo se consultan las cuentas del dominio
+// de su repositorio y se muestran solo las versiones del módulo.
+func runVersionsAllAccounts(cfg *config.Config, library string, repo *moduleRepo) error {
+	accounts, err := versionsAccounts.accounts(cfg)
+	if err != nil {
+		color.Red("✗ %v", err)
+		return err
+	}
+
+	if repo != nil {
+		host, _, _ := strings.Cut(repo.Repo, "/")
+		var sameDomain []*config.Account
+		for _, acc := range accounts {
+			if normalizeAccountDomain(acc.Domain) == host {
+				sameDomain = append(sameDomain, acc)
+			}
+		}
+		if len(sameDomain) == 0 {
+			err := fmt.Errorf("ninguna de las cuentas seleccionadas es del dominio %s", host)
+			color.Red("✗ %v", err)
+			return err
+		}
+		accounts = sameDomain
+	}
+
+	results := make([][]api.Version, len(accounts))
+	found := make([]bool, len(accounts))
+	errs := forEachAccount(accounts, func(i int, acc *config.Account) error {
+		provider, err := newCachedProvider(cfg, acc, versionsCache)
+		if err != nil {
+			return err
+		}
+
+		versions, err := provider.ListVersions(library)
+		if errors.Is(err, api.ErrNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if repo != nil {
+			versions = moduleTagVersions(repo, versions)
+		} else {
+			sortVersionsDesc(versions)
+		}
+		results[i], found[i] = versions, true
+		return nil
+	})
+
+	// Las cuentas del mismo dominio ven el mismo repositorio
+	byModule := make(map[string]*accountVersions)
+	var modules []*accountVersions
+	for i, acc := range accounts {
+		if !found[i] {
+			continue
+		}
+		module := normalizeAccountDomain(acc.Domain) + "/" + library
+		if repo != nil {
+			module = repo.Module
+		}
+		m := byModule[module]
+		if m == nil {
+			m = &accountVersions{Module: module, Versions: results[i]}
+			byModule[module] = m
+			modules = append(modules, m)
+		}
+		m.Accounts = append(m.Accounts, acc)
+	}
+	sort.Slice(modules, func(i, j int) bool {
+		return modules[i].Module < modules[j].Module
+	})
+
+	cyan := color.New(color.FgCyan)
+	magenta := color.New(color.FgMagenta)
+	blue := color.New(color.FgBlue)
+	gray := color.New(color.FgWhite)
+
+	for _, m := range modules {
+		fmt.Println()
+		cyan.Printf("%s", m.Module)
+		magenta.Printf("  (cuentas: %s)\n", sortedAccountNames(m.Accounts))
+
+		if len(m.Versions) == 0 {
+			gray.Println("  sin versiones publicadas")
+			continue
+		}
+		for _, v := range m.Versions {
+			blue.Printf("  %-12s", v.Name)
+			gray.Printf(" %s\n", v.Date)
+		}
+	}
+
+	if len(modules) == 0 {
+		if repo != nil {
+			library = repo.Module
+		}
+		fmt.Println()
+		color.Yellow("No se encontró '%s' en las cuentas consultadas", library)
+	}
+	fmt.Println()
+
+	return printAccountFailures(accounts, errs)
+}
+
+// moduleTagVersions filtra los tags del repositorio a las versiones del
+// módulo (prefijo del subdirectorio y versión mayor del module path). Las
+// versiones se retornan sin el prefijo, de la más reciente a la más antigua.
+func moduleTagVersions(repo *moduleRepo, tags []api.Version) []api.Version {
+	names := make([]string, len(tags))
+	dates := make(map[string]string, len(tags))
+	for i, t := range tags {
+		names[i] = t.Name
+		dates[t.Name] = t.Date
+	}
+
+	versions := repo.ModuleVersions(repo.TagVersions(names))
+	result := make([]api.Version, 0, len(versions))
+	for i := len(versions) - 1; i >= 0; i-- {
+		v := versions[i]
+		result = append(result, api.Version{Name: v, Date: dates[repo.Tag(v)]})
+	}
+	return result
+}
+
+// sortVersionsDesc ordena los tags de la versión más reciente a la más antigua
+func sortVersionsDesc(versions []api.Version) {
+	sort.SliceStable(versions, func(i, j int) bool {
+		return gomod.CompareVersions(versions[i].Name, versions[j].Name) > 0
+	})
+}
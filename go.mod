@@ -1,11 +1,12 @@
 module github.com/reitmas32/next
 
-go 1.22
+go 1.22.0
 
 require (
 	github.com/fatih/color v1.16.0
 	github.com/spf13/cobra v1.8.0
 	github.com/zalando/go-keyring v0.2.6
+	golang.org/x/mod v0.21.0
 )
 
 require (
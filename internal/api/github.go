@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"strings"
 	"time"
 )
@@ -154,11 +155,13 @@ func (g *GitHubProvider) ListGoLibrariesWithOptions(opts ListOptions) ([]Library
 		}
 
 		var repos []struct {
-			Name        string `json:"name"`
-			FullName    string `json:"full_name"`
-			Description string `json:"description"`
-			HTMLURL     string `json:"html_url"`
-			Private     bool   `json:"private"`
+			Name        string   `json:"name"`
+			FullName    string   `json:"full_name"`
+			Description string   `json:"description"`
+			HTMLURL     string   `json:"html_url"`
+			Private     bool     `json:"private"`
+			Topics      []string `json:"topics"`
+			Language    string   `json:"language"`
 		}
 
 		body, _ := io.ReadAll(resp.Body)
@@ -182,10 +185,13 @@ func (g *GitHubProvider) ListGoLibrariesWithOptions(opts ListOptions) ([]Library
 
 				libraries = append(libraries, Library{
 					Name:        r.Name,
+					Path:        r.FullName,
 					Description: r.Description,
 					URL:         r.HTMLURL,
 					Provider:    "github",
 					Visibility:  visibility,
+					Topics:      r.Topics,
+					Language:    r.Language,
 				})
 			}
 		}
@@ -217,43 +223,96 @@ func (g *GitHubProvider) hasGoMod(fullName string) bool {
 	return resp.StatusCode == http.StatusOK
 }
 
-// ListVersions lista todas las versiones de una librería
-func (g *GitHubProvider) ListVersions(library string) ([]Version, error) {
-	apiURL := fmt.Sprintf("%s/repos/%s/tags", g.apiURL, library)
+// githubTag es un tag de la API de GitHub
+type githubTag struct {
+	Name   string `json:"name"`
+	Commit struct {
+		SHA string `json:"sha"`
+	} `json:"commit"`
+}
 
-	req, err := http.NewRequest("GET", apiURL, nil)
-	if err != nil {
-		return nil, err
+// listTags lista todos los tags de un repositorio siguiendo la paginación
+// (header Link con rel="next")
+func (g *GitHubProvider) listTags(library string) ([]githubTag, error) {
+	var tags []githubTag
+
+	apiURL := fmt.Sprintf("%s/repos/%s/tags?per_page=%d", g.apiURL, library, tagsPerPage)
+	for apiURL != "" {
+		req, err := http.NewRequest("GET", apiURL, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		req.Header.Set("Authorization", "Bearer "+g.token)
+		req.Header.Set("Accept", "application/vnd.github+json")
+
+		resp, err := g.client.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("error de conexión: %w", err)
+		}
+
+		switch resp.StatusCode {
+		case http.StatusOK:
+		case http.StatusNotFound:
+			resp.Body.Close()
+			return nil, ErrNotFound
+		default:
+			resp.Body.Close()
+			return nil, fmt.Errorf("error al obtener tags (status: %d)", resp.StatusCode)
+		}
+
+		var page []githubTag
+		err = json.NewDecoder(resp.Body).Decode(&page)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("error al decodificar respuesta: %w", err)
+		}
+
+		tags = append(tags, page...)
+		apiURL = nextPageLink(resp.Header.Get("Link"))
 	}
 
-	req.Header.Set("Authorization", "Bearer "+g.token)
-	req.Header.Set("Accept", "application/vnd.github+json")
+	return tags, nil
+}
 
-	resp, err := g.client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("error de conexión: %w", err)
+// nextPageLink extrae la URL rel="next" de un header Link de GitHub
+func nextPageLink(header string) string {
+	for _, part := range strings.Split(header, ",") {
+		target, params, ok := strings.Cut(strings.TrimSpace(part), ";")
+		if !ok || !strings.Contains(params, `rel="next"`) {
+			continue
+		}
+		return strings.Trim(strings.TrimSpace(target), "<>")
 	}
-	defer resp.Body.Close()
+	return ""
+}
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("error al obtener tags (status: %d)", resp.StatusCode)
+// ListTags lista los nombres de todos los tags de una librería
+func (g *GitHubProvider) ListTags(library string) ([]string, error) {
+	tags, err := g.listTags(library)
+	if err != nil {
+		return nil, err
 	}
 
-	var tags []struct {
-		Name   string `json:"name"`
-		Commit struct {
-			SHA string `json:"sha"`
-		} `json:"commit"`
+	names := make([]string, len(tags))
+	for i, t := range tags {
+		names[i] = t.Name
 	}
+	return names, nil
+}
 
-	if err := json.NewDecoder(resp.Body).Decode(&tags); err != nil {
-		return nil, fmt.Errorf("error al decodificar respuesta: %w", err)
+// ListVersions lista todas las versiones de una librería con la fecha de
+// su commit (una consulta por tag; use ListTags si solo necesita los nombres)
+func (g *GitHubProvider) ListVersions(library string) ([]Version, error) {
+	tags, err := g.listTags(library)
+	if err != nil {
+		return nil, err
 	}
 
 	var versions []Version
 	for _, t := range tags {
 		// Obtener fecha del commit
-		date := g.getCommitDate(library, t.Commit.SHA)
+		date := g.GetCommitDate(library, t.Commit.SHA)
 		versions = append(versions, Version{
 			Name: t.Name,
 			Date: date,
@@ -263,9 +322,9 @@ func (g *GitHubProvider) ListVersions(library string) ([]Version, error) {
 	return versions, nil
 }
 
-// getCommitDate obtiene la fecha de un commit
-func (g *GitHubProvider) getCommitDate(repo, sha string) string {
-	url := fmt.Sprintf("%s/repos/%s/commits/%s", g.apiURL, repo, sha)
+// GetCommitDate obtiene la fecha de un commit (sha, tag o rama)
+func (g *GitHubProvider) GetCommitDate(repo, ref string) string {
+	url := fmt.Sprintf("%s/repos/%s/commits/%s", g.apiURL, repo, ref)
 
 	req, err := http.NewRequest("GET", url, nil)
 	if err != nil {
@@ -281,6 +340,10 @@ func (g *GitHubProvider) getCommitDate(repo, sha string) string {
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode != http.StatusOK {
+		return ""
+	}
+
 	var commit struct {
 		Commit struct {
 			Author struct {
@@ -396,3 +459,305 @@ func (g *GitHubProvider) getDefaultBranchSHA(repoPath string) (string, error) {
 
 	return branch.Commit.SHA, nil
 }
+
+// GetRepository retorna los metadatos de un repositorio
+func (g *GitHubProvider) GetRepository(repoPath string) (*Repository, error) {
+	apiURL := fmt.Sprintf("%s/repos/%s", g.apiURL, repoPath)
+
+	req, err := http.NewRequest("GET", apiURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Authorization", "Bearer "+g.token)
+	req.Header.Set("Accept", "application/vnd.github+json")
+
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error de conexión: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return nil, ErrNotFound
+	default:
+		return nil, fmt.Errorf("error al obtener repositorio (status: %d)", resp.StatusCode)
+	}
+
+	var repo struct {
+		Name          string   `json:"name"`
+		FullName      string   `json:"full_name"`
+		Description   string   `json:"description"`
+		HTMLURL       string   `json:"html_url"`
+		Private       bool     `json:"private"`
+		Visibility    string   `json:"visibility"`
+		DefaultBranch string   `json:"default_branch"`
+		Topics        []string `json:"topics"`
+		License       *struct {
+			SPDXID string `json:"spdx_id"`
+			Name   string `json:"name"`
+		} `json:"license"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&repo); err != nil {
+		return nil, fmt.Errorf("error al decodificar respuesta: %w", err)
+	}
+
+	result := &Repository{
+		Name:          repo.Name,
+		Path:          repo.FullName,
+		Description:   repo.Description,
+		URL:           repo.HTMLURL,
+		Visibility:    repo.Visibility,
+		DefaultBranch: repo.DefaultBranch,
+		Topics:        repo.Topics,
+	}
+
+	// GitHub Enterprise antiguo no retorna visibility
+	if result.Visibility == "" {
+		result.Visibility = "public"
+		if repo.Private {
+			result.Visibility = "private"
+		}
+	}
+
+	// NOASSERTION: GitHub detectó un archivo de licencia pero no su tipo
+	if repo.License != nil {
+		result.License = repo.License.SPDXID
+		if result.License == "" || result.License == "NOASSERTION" {
+			result.License = repo.License.Name
+		}
+	}
+
+	if repo.DefaultBranch != "" {
+		result.LastCommit = g.GetCommitDate(repoPath, repo.DefaultBranch)
+	}
+
+	return result, nil
+}
+
+// GetFile retorna el contenido de un archivo del repositorio
+func (g *GitHubProvider) GetFile(repoPath, filePath, ref string) ([]byte, error) {
+	apiURL := fmt.Sprintf("%s/repos/%s/contents/%s", g.apiURL, repoPath, filePath)
+	if ref != "" {
+		apiURL += "?ref=" + url.QueryEscape(ref)
+	}
+
+	req, err := http.NewRequest("GET", apiURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Authorization", "Bearer "+g.token)
+	req.Header.Set("Accept", "application/vnd.github.raw")
+
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error de conexión: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return io.ReadAll(resp.Body)
+	case http.StatusNotFound:
+		return nil, ErrNotFound
+	default:
+		return nil, fmt.Errorf("error al obtener %s (status: %d)", filePath, resp.StatusCode)
+	}
+}
+
+// codeSearchQueryLength es el largo máximo de una consulta de búsqueda de código
+const codeSearchQueryLength = 256
+
+// SearchCode busca texto en los archivos Go de los repositorios indicados. La
+// búsqueda se limita a sus owners (user:) y luego se filtra por repositorio.
+func (g *GitHubProvider) SearchCode(query string, repoPaths []string) ([]CodeMatch, error) {
+	wanted := make(map[string]bool)
+	seenOwner := make(map[string]bool)
+	var owners []string
+	for _, p := range repoPaths {
+		wanted[p] = true
+		owner, _, _ := strings.Cut(p, "/")
+		if !seenOwner[owner] {
+			seenOwner[owner] = true
+			owners = append(owners, owner)
+		}
+	}
+
+	// Agrupar owners sin pasar del largo máximo de la consulta
+	var queries []string
+	current := query + " language:go"
+	for _, owner := range owners {
+		qualifier := " user:" + owner
+		if len(current)+len(qualifier) > codeSearchQueryLength && current != query+" language:go" {
+			queries = append(queries, current)
+			current = query + " language:go"
+		}
+		current += qualifier
+	}
+	queries = append(queries, current)
+
+	var matches []CodeMatch
+	for _, q := range queries {
+		found, err := g.searchCode(q)
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range found {
+			if wanted[m.RepoPath] {
+				matches = append(matches, m)
+			}
+		}
+	}
+
+	return matches, nil
+}
+
+// searchCode ejecuta una consulta de búsqueda de código (hasta 10 páginas)
+func (g *GitHubProvider) searchCode(q string) ([]CodeMatch, error) {
+	var matches []CodeMatch
+	perPage := 100
+
+	for page := 1; page <= 10; page++ {
+		apiURL := fmt.Sprintf("%s/search/code?q=%s&per_page=%d&page=%d", g.apiURL, url.QueryEscape(q), perPage, page)
+
+		req, err := http.NewRequest("GET", apiURL, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		req.Header.Set("Authorization", "Bearer "+g.token)
+		req.Header.Set("Accept", "application/vnd.github.text-match+json")
+
+		resp, err := g.client.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("error de conexión: %w", err)
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			body, _ := io.ReadAll(resp.Body)
+			resp.Body.Close()
+			return nil, fmt.Errorf("error en la búsqueda de código (status: %d): %s", resp.StatusCode, string(body))
+		}
+
+		var result struct {
+			Items []struct {
+				Path       string `json:"path"`
+				Repository struct {
+					FullName string `json:"full_name"`
+				} `json:"repository"`
+				TextMatches []struct {
+					Fragment string `json:"fragment"`
+				} `json:"text_matches"`
+			} `json:"items"`
+		}
+
+		err = json.NewDecoder(resp.Body).Decode(&result)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("error al decodificar respuesta: %w", err)
+		}
+
+		for _, item := range result.Items {
+			var fragments []string
+			for _, tm := range item.TextMatches {
+				fragments = append(fragments, tm.Fragment)
+			}
+			matches = append(matches, CodeMatch{
+				RepoPath: item.Repository.FullName,
+				Path:     item.Path,
+				Fragment: strings.Join(fragments, "\n"),
+			})
+		}
+
+		if len(result.Items) < perPage {
+			break
+		}
+	}
+
+	return matches, nil
+}
+
+// CreatePullRequest abre un pull request y retorna su URL
+func (g *GitHubProvider) CreatePullRequest(repoPath string, pr PullRequest) (string, error) {
+	apiURL := fmt.Sprintf("%s/repos/%s/pulls", g.apiURL, repoPath)
+
+	payload := map[string]string{
+		"title": pr.Title,
+		"body":  pr.Body,
+		"head":  pr.Head,
+		"base":  pr.Base,
+	}
+
+	body, _ := json.Marshal(payload)
+
+	req, err := http.NewRequest("POST", apiURL, bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+
+	req.Header.Set("Authorization", "Bearer "+g.token)
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("error de conexión: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		respBody, _ := io.ReadAll(resp.Body)
+		return "", fmt.Errorf("error al crear pull request (status: %d): %s", resp.StatusCode, string(respBody))
+	}
+
+	var created struct {
+		HTMLURL string `json:"html_url"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&created); err != nil {
+		return "", fmt.Errorf("error al decodificar respuesta: %w", err)
+	}
+
+	return created.HTMLURL, nil
+}
+
+// FindPullRequest retorna la URL del pull request abierto desde la rama head
+// del mismo repositorio, o vacío si no hay ninguno
+func (g *GitHubProvider) FindPullRequest(repoPath, head string) (string, error) {
+	owner, _, _ := strings.Cut(repoPath, "/")
+	apiURL := fmt.Sprintf("%s/repos/%s/pulls?state=open&head=%s", g.apiURL, repoPath, url.QueryEscape(owner+":"+head))
+
+	req, err := http.NewRequest("GET", apiURL, nil)
+	if err != nil {
+		return "", err
+	}
+
+	req.Header.Set("Authorization", "Bearer "+g.token)
+	req.Header.Set("Accept", "application/vnd.github+json")
+
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("error de conexión: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return "", fmt.Errorf("error al buscar pull requests (status: %d): %s", resp.StatusCode, string(body))
+	}
+
+	var pulls []struct {
+		HTMLURL string `json:"html_url"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&pulls); err != nil {
+		return "", fmt.Errorf("error al decodificar respuesta: %w", err)
+	}
+
+	if len(pulls) == 0 {
+		return "", nil
+	}
+	return pulls[0].HTMLURL, nil
+}
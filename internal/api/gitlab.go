@@ -120,12 +120,14 @@ func (g *GitLabProvider) ListGoLibrariesWithOptions(opts ListOptions) ([]Library
 		}
 
 		var projects []struct {
-			ID          int    `json:"id"`
-			Name        string `json:"name"`
-			Description string `json:"description"`
-			WebURL      string `json:"web_url"`
-			PathWithNS  string `json:"path_with_namespace"`
-			Visibility  string `json:"visibility"` // "public", "internal", "private"
+			ID          int      `json:"id"`
+			Name        string   `json:"name"`
+			Description string   `json:"description"`
+			WebURL      string   `json:"web_url"`
+			PathWithNS  string   `json:"path_with_namespace"`
+			Visibility  string   `json:"visibility"` // "public", "internal", "private"
+			Topics      []string `json:"topics"`
+			TagList     []string `json:"tag_list"`
 		}
 
 		body, _ := io.ReadAll(resp.Body)
@@ -147,13 +149,24 @@ func (g *GitLabProvider) ListGoLibrariesWithOptions(opts ListOptions) ([]Library
 					visibility = "private"
 				}
 
-				libraries = append(libraries, Library{
+				topics := p.Topics
+				if len(topics) == 0 {
+					topics = p.TagList
+				}
+
+				lib := Library{
 					Name:        p.Name,
+					Path:        p.PathWithNS,
 					Description: p.Description,
 					URL:         p.WebURL,
 					Provider:    "gitlab",
 					Visibility:  visibility,
-				})
+					Topics:      topics,
+				}
+				if opts.WithLanguage {
+					lib.Language = g.primaryLanguage(p.ID)
+				}
+				libraries = append(libraries, lib)
 			}
 		}
 
@@ -163,6 +176,38 @@ func (g *GitLabProvider) ListGoLibrariesWithOptions(opts ListOptions) ([]Library
 	return libraries, nil
 }
 
+// primaryLanguage retorna el lenguaje con mayor porcentaje de un proyecto
+// (el listado de proyectos de GitLab no lo incluye)
+func (g *GitLabProvider) primaryLanguage(projectID int) string {
+	url := fmt.Sprintf("%s/projects/%d/languages", g.apiURL, projectID)
+
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return ""
+	}
+
+	req.Header.Set("PRIVATE-TOKEN", g.token)
+
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return ""
+	}
+	defer resp.Body.Close()
+
+	var languages map[string]float64
+	if err := json.NewDecoder(resp.Body).Decode(&languages); err != nil {
+		return ""
+	}
+
+	primary, best := "", 0.0
+	for name, percent := range languages {
+		if percent > best || (percent == best && name < primary) {
+			primary, best = name, percent
+		}
+	}
+	return primary
+}
+
 // hasGoMod verifica si un proyecto tiene archivo go.mod
 func (g *GitLabProvider) hasGoMod(projectID int) bool {
 	url := fmt.Sprintf("%s/projects/%d/repository/files/go.mod?ref=main", g.apiURL, projectID)
@@ -203,38 +248,79 @@ func (g *GitLabProvider) hasGoMod(projectID int) bool {
 	return resp.StatusCode == http.StatusOK
 }
 
-// ListVersions lista todas las versiones de una librería
-func (g *GitLabProvider) ListVersions(library string) ([]Version, error) {
+// gitlabTag es un tag de la API de GitLab
+type gitlabTag struct {
+	Name   string `json:"name"`
+	Commit struct {
+		CreatedAt time.Time `json:"created_at"`
+	} `json:"commit"`
+}
+
+// listTags lista todos los tags de un proyecto siguiendo la paginación
+// (header X-Next-Page)
+func (g *GitLabProvider) listTags(library string) ([]gitlabTag, error) {
 	// Codificar el path del proyecto
 	encodedPath := url.PathEscape(library)
-	apiURL := fmt.Sprintf("%s/projects/%s/repository/tags", g.apiURL, encodedPath)
 
-	req, err := http.NewRequest("GET", apiURL, nil)
-	if err != nil {
-		return nil, err
-	}
+	var tags []gitlabTag
+	for page := "1"; page != ""; {
+		apiURL := fmt.Sprintf("%s/projects/%s/repository/tags?per_page=%d&page=%s", g.apiURL, encodedPath, tagsPerPage, page)
 
-	req.Header.Set("PRIVATE-TOKEN", g.token)
+		req, err := http.NewRequest("GET", apiURL, nil)
+		if err != nil {
+			return nil, err
+		}
 
-	resp, err := g.client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("error de conexión: %w", err)
+		req.Header.Set("PRIVATE-TOKEN", g.token)
+
+		resp, err := g.client.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("error de conexión: %w", err)
+		}
+
+		switch resp.StatusCode {
+		case http.StatusOK:
+		case http.StatusNotFound:
+			resp.Body.Close()
+			return nil, ErrNotFound
+		default:
+			resp.Body.Close()
+			return nil, fmt.Errorf("error al obtener tags (status: %d)", resp.StatusCode)
+		}
+
+		var batch []gitlabTag
+		err = json.NewDecoder(resp.Body).Decode(&batch)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("error al decodificar respuesta: %w", err)
+		}
+
+		tags = append(tags, batch...)
+		page = strings.TrimSpace(resp.Header.Get("X-Next-Page"))
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("error al obtener tags (status: %d)", resp.StatusCode)
+	return tags, nil
+}
+
+// ListTags lista los nombres de todos los tags de una librería
+func (g *GitLabProvider) ListTags(library string) ([]string, error) {
+	tags, err := g.listTags(library)
+	if err != nil {
+		return nil, err
 	}
 
-	var tags []struct {
-		Name   string `json:"name"`
-		Commit struct {
-			CreatedAt time.Time `json:"created_at"`
-		} `json:"commit"`
+	names := make([]string, len(tags))
+	for i, t := range tags {
+		names[i] = t.Name
 	}
+	return names, nil
+}
 
-	if err := json.NewDecoder(resp.Body).Decode(&tags); err != nil {
-		return nil, fmt.Errorf("error al decodificar respuesta: %w", err)
+// ListVersions lista todas las versiones de una librería
+func (g *GitLabProvider) ListVersions(library string) ([]Version, error) {
+	tags, err := g.listTags(library)
+	if err != nil {
+		return nil, err
 	}
 
 	var versions []Version
@@ -318,3 +404,275 @@ func (g *GitLabProvider) getDefaultBranchRef(repoPath string) (string, error) {
 
 	return project.DefaultBranch, nil
 }
+
+// GetRepository retorna los metadatos de un proyecto
+func (g *GitLabProvider) GetRepository(repoPath string) (*Repository, error) {
+	apiURL := fmt.Sprintf("%s/projects/%s?license=true", g.apiURL, url.PathEscape(repoPath))
+
+	req, err := http.NewRequest("GET", apiURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("PRIVATE-TOKEN", g.token)
+
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error de conexión: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return nil, ErrNotFound
+	default:
+		return nil, fmt.Errorf("error al obtener proyecto (status: %d)", resp.StatusCode)
+	}
+
+	var project struct {
+		Name              string   `json:"name"`
+		PathWithNamespace string   `json:"path_with_namespace"`
+		Description       string   `json:"description"`
+		WebURL            string   `json:"web_url"`
+		Visibility        string   `json:"visibility"`
+		DefaultBranch     string   `json:"default_branch"`
+		Topics            []string `json:"topics"`
+		TagList           []string `json:"tag_list"`
+		License           *struct {
+			Key      string `json:"key"`
+			Nickname string `json:"nickname"`
+			Name     string `json:"name"`
+		} `json:"license"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&project); err != nil {
+		return nil, fmt.Errorf("error al decodificar respuesta: %w", err)
+	}
+
+	result := &Repository{
+		Name:          project.Name,
+		Path:          project.PathWithNamespace,
+		Description:   project.Description,
+		URL:           project.WebURL,
+		Visibility:    project.Visibility,
+		DefaultBranch: project.DefaultBranch,
+		Topics:        project.Topics,
+	}
+
+	// Versiones anteriores a GitLab 14.5 solo retornan tag_list
+	if len(result.Topics) == 0 {
+		result.Topics = project.TagList
+	}
+
+	if project.License != nil {
+		result.License = project.License.Nickname
+		if result.License == "" {
+			result.License = project.License.Name
+		}
+	}
+
+	if project.DefaultBranch != "" {
+		result.LastCommit = g.GetCommitDate(repoPath, project.DefaultBranch)
+	}
+
+	return result, nil
+}
+
+// GetCommitDate obtiene la fecha de un commit (sha, tag o último commit de una rama)
+func (g *GitLabProvider) GetCommitDate(repoPath, ref string) string {
+	apiURL := fmt.Sprintf("%s/projects/%s/repository/commits/%s", g.apiURL, url.PathEscape(repoPath), url.PathEscape(ref))
+
+	req, err := http.NewRequest("GET", apiURL, nil)
+	if err != nil {
+		return ""
+	}
+
+	req.Header.Set("PRIVATE-TOKEN", g.token)
+
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return ""
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return ""
+	}
+
+	var commit struct {
+		CommittedDate time.Time `json:"committed_date"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&commit); err != nil {
+		return ""
+	}
+
+	return commit.CommittedDate.Format("2006-01-02")
+}
+
+// GetFile retorna el contenido de un archivo del repositorio
+func (g *GitLabProvider) GetFile(repoPath, filePath, ref string) ([]byte, error) {
+	if ref == "" {
+		ref = "HEAD"
+	}
+
+	apiURL := fmt.Sprintf("%s/projects/%s/repository/files/%s/raw?ref=%s",
+		g.apiURL, url.PathEscape(repoPath), url.PathEscape(filePath), url.QueryEscape(ref))
+
+	req, err := http.NewRequest("GET", apiURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("PRIVATE-TOKEN", g.token)
+
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error de conexión: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return io.ReadAll(resp.Body)
+	case http.StatusNotFound:
+		return nil, ErrNotFound
+	default:
+		return nil, fmt.Errorf("error al obtener %s (status: %d)", filePath, resp.StatusCode)
+	}
+}
+
+// SearchCode busca texto en los archivos Go de los proyectos indicados. Se usa
+// la búsqueda por proyecto porque la de grupo requiere búsqueda avanzada.
+func (g *GitLabProvider) SearchCode(query string, repoPaths []string) ([]CodeMatch, error) {
+	var matches []CodeMatch
+
+	for _, repoPath := range repoPaths {
+		apiURL := fmt.Sprintf("%s/projects/%s/search?scope=blobs&search=%s&per_page=100",
+			g.apiURL, url.PathEscape(repoPath), url.QueryEscape(query))
+
+		req, err := http.NewRequest("GET", apiURL, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		req.Header.Set("PRIVATE-TOKEN", g.token)
+
+		resp, err := g.client.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("error de conexión: %w", err)
+		}
+
+		// Proyectos sin repositorio o sin acceso a la búsqueda
+		if resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusForbidden {
+			resp.Body.Close()
+			continue
+		}
+		if resp.StatusCode != http.StatusOK {
+			body, _ := io.ReadAll(resp.Body)
+			resp.Body.Close()
+			return nil, fmt.Errorf("error en la búsqueda de código de %s (status: %d): %s", repoPath, resp.StatusCode, string(body))
+		}
+
+		var blobs []struct {
+			Path string `json:"path"`
+			Data string `json:"data"`
+		}
+
+		err = json.NewDecoder(resp.Body).Decode(&blobs)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("error al decodificar respuesta: %w", err)
+		}
+
+		for _, b := range blobs {
+			if !strings.HasSuffix(b.Path, ".go") {
+				continue
+			}
+			matches = append(matches, CodeMatch{
+				RepoPath: repoPath,
+				Path:     b.Path,
+				Fragment: b.Data,
+			})
+		}
+	}
+
+	return matches, nil
+}
+
+// CreatePullRequest abre un merge request y retorna su URL
+func (g *GitLabProvider) CreatePullRequest(repoPath string, pr PullRequest) (string, error) {
+	apiURL := fmt.Sprintf("%s/projects/%s/merge_requests", g.apiURL, url.PathEscape(repoPath))
+
+	data := url.Values{}
+	data.Set("source_branch", pr.Head)
+	data.Set("target_branch", pr.Base)
+	data.Set("title", pr.Title)
+	data.Set("description", pr.Body)
+	data.Set("remove_source_branch", "true")
+
+	req, err := http.NewRequest("POST", apiURL, strings.NewReader(data.Encode()))
+	if err != nil {
+		return "", err
+	}
+
+	req.Header.Set("PRIVATE-TOKEN", g.token)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("error de conexión: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		body, _ := io.ReadAll(resp.Body)
+		return "", fmt.Errorf("error al crear merge request (status: %d): %s", resp.StatusCode, string(body))
+	}
+
+	var created struct {
+		WebURL string `json:"web_url"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&created); err != nil {
+		return "", fmt.Errorf("error al decodificar respuesta: %w", err)
+	}
+
+	return created.WebURL, nil
+}
+
+// FindPullRequest retorna la URL del merge request abierto desde la rama
+// head, o vacío si no hay ninguno
+func (g *GitLabProvider) FindPullRequest(repoPath, head string) (string, error) {
+	apiURL := fmt.Sprintf("%s/projects/%s/merge_requests?state=opened&source_branch=%s", g.apiURL, url.PathEscape(repoPath), url.QueryEscape(head))
+
+	req, err := http.NewRequest("GET", apiURL, nil)
+	if err != nil {
+		return "", err
+	}
+
+	req.Header.Set("PRIVATE-TOKEN", g.token)
+
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("error de conexión: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return "", fmt.Errorf("error al buscar merge requests (status: %d): %s", resp.StatusCode, string(body))
+	}
+
+	var requests []struct {
+		WebURL string `json:"web_url"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&requests); err != nil {
+		return "", fmt.Errorf("error al decodificar respuesta: %w", err)
+	}
+
+	if len(requests) == 0 {
+		return "", nil
+	}
+	return requests[0].WebURL, nil
+}
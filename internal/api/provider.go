@@ -1,9 +1,17 @@
 package api
 
 import (
+	"errors"
 	"fmt"
+	"net/http"
 )
 
+// ErrNotFound indica que el recurso solicitado no existe en el proveedor
+var ErrNotFound = errors.New("no encontrado")
+
+// tagsPerPage es el tamaño de página al listar tags (el máximo de ambas APIs)
+const tagsPerPage = 100
+
 // Visibility define el tipo de visibilidad de los repositorios
 type Visibility string
 
@@ -19,10 +27,13 @@ const (
 // Library representa una librería Go
 type Library struct {
 	Name        string
+	Path        string // path completo del repositorio (ej: org/lib o grupo/subgrupo/lib)
 	Description string
 	URL         string
 	Provider    string
 	Visibility  string // "public" o "private"
+	Topics      []string
+	Language    string // lenguaje principal (en GitLab solo con ListOptions.WithLanguage)
 }
 
 // Version representa una versión/tag de una librería
@@ -31,11 +42,49 @@ type Version struct {
 	Date string
 }
 
+// Repository contiene los metadatos de un repositorio
+type Repository struct {
+	Name          string
+	Path          string
+	Description   string
+	URL           string
+	Visibility    string
+	DefaultBranch string
+	// License es el identificador SPDX de la licencia (vacío si no se detectó)
+	License string
+	Topics  []string
+	// LastCommit es la fecha del último commit de la rama por defecto (YYYY-MM-DD)
+	LastCommit string
+}
+
+// CodeMatch es un archivo que coincide con una búsqueda de código
+type CodeMatch struct {
+	// RepoPath es el path del repositorio (ej: org/lib)
+	RepoPath string
+	// Path es el archivo dentro del repositorio
+	Path string
+	// Fragment es el texto que coincidió (puede incluir varias líneas)
+	Fragment string
+}
+
+// PullRequest describe un pull request (GitHub) o merge request (GitLab)
+type PullRequest struct {
+	Title string
+	Body  string
+	// Head es la rama con los cambios
+	Head string
+	// Base es la rama destino
+	Base string
+}
+
 // ListOptions opciones para listar librerías
 type ListOptions struct {
 	Visibility Visibility
 	// Owner permite filtrar por usuario/organización (opcional)
 	Owner string
+	// WithLanguage completa Library.Language; en GitLab cuesta una consulta
+	// extra por proyecto, por eso solo se pide cuando se filtra por lenguaje
+	WithLanguage bool
 }
 
 // Provider define la interfaz para interactuar con proveedores Git
@@ -52,11 +101,41 @@ type Provider interface {
 	// ListGoLibrariesWithOptions lista librerías con opciones de filtrado
 	ListGoLibrariesWithOptions(opts ListOptions) ([]Library, error)
 
-	// ListVersions lista todas las versiones de una librería
+	// ListVersions lista todas las versiones de una librería con su fecha.
+	// Retorna ErrNotFound si la librería no existe o la cuenta no tiene acceso.
 	ListVersions(library string) ([]Version, error)
 
+	// ListTags lista los nombres de todos los tags de una librería, sin
+	// consultar fechas. Retorna ErrNotFound igual que ListVersions.
+	ListTags(library string) ([]string, error)
+
+	// GetCommitDate retorna la fecha (YYYY-MM-DD) del commit de una
+	// referencia (sha, tag o rama), o vacío si no se pudo obtener
+	GetCommitDate(repoPath, ref string) string
+
 	// CreateTag crea un tag en un repositorio
 	CreateTag(repoPath, tag string) error
+
+	// GetRepository retorna los metadatos de un repositorio. Retorna
+	// ErrNotFound si no existe o la cuenta no tiene acceso.
+	GetRepository(repoPath string) (*Repository, error)
+
+	// GetFile retorna el contenido de un archivo en una referencia (tag,
+	// rama o commit; vacía para la rama por defecto). Retorna ErrNotFound
+	// si el archivo o la referencia no existen.
+	GetFile(repoPath, filePath, ref string) ([]byte, error)
+
+	// SearchCode busca texto en los archivos .go de los repositorios
+	// indicados (paths como org/lib) con la búsqueda de código del proveedor
+	SearchCode(query string, repoPaths []string) ([]CodeMatch, error)
+
+	// CreatePullRequest abre un pull request (merge request en GitLab) y
+	// retorna su URL
+	CreatePullRequest(repoPath string, pr PullRequest) (string, error)
+
+	// FindPullRequest retorna la URL del pull request (merge request en
+	// GitLab) abierto desde la rama head, o vacío si no hay ninguno
+	FindPullRequest(repoPath, head string) (string, error)
 }
 
 // NewProvider crea un nuevo proveedor según el tipo
@@ -70,3 +149,14 @@ func NewProvider(providerType, baseURL, token string) (Provider, error) {
 		return nil, fmt.Errorf("proveedor no soportado: %s", providerType)
 	}
 }
+
+// SetTransport reemplaza el transporte HTTP del cliente de un proveedor (ej:
+// para pasar las consultas por el cache)
+func SetTransport(p Provider, rt http.RoundTripper) {
+	switch p := p.(type) {
+	case *GitHubProvider:
+		p.client.Transport = rt
+	case *GitLabProvider:
+		p.client.Transport = rt
+	}
+}
@@ -0,0 +1,97 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+// testTags genera más tags que una página de la API
+func testTags(n int) []string {
+	tags := make([]string, n)
+	for i := range tags {
+		tags[i] = fmt.Sprintf("v1.0.%d", i)
+	}
+	return tags
+}
+
+// pageOf retorna la página indicada de tags y si hay una siguiente
+func pageOf(tags []string, r *http.Request) ([]map[string]string, bool) {
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	if page == 0 {
+		page = 1
+	}
+	perPage, _ := strconv.Atoi(r.URL.Query().Get("per_page"))
+
+	start := min((page-1)*perPage, len(tags))
+	end := min(start+perPage, len(tags))
+
+	var items []map[string]string
+	for _, t := range tags[start:end] {
+		items = append(items, map[string]string{"name": t})
+	}
+	return items, end < len(tags)
+}
+
+func TestGitHubListTagsFollowsLinkHeader(t *testing.T) {
+	tags := testTags(250)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v3/repos/org/lib/tags" {
+			http.NotFound(w, r)
+			return
+		}
+		items, more := pageOf(tags, r)
+		if more {
+			page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+			next := fmt.Sprintf("http://%s%s?per_page=%s&page=%d", r.Host, r.URL.Path, r.URL.Query().Get("per_page"), max(page, 1)+1)
+			w.Header().Set("Link", fmt.Sprintf(`<%s>; rel="next", <%s>; rel="last"`, next, next))
+		}
+		json.NewEncoder(w).Encode(items)
+	}))
+	defer srv.Close()
+
+	got, err := NewGitHubProvider(srv.URL, "token").ListTags("org/lib")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, tags) {
+		t.Fatalf("se obtuvieron %d tags, se esperaban %d", len(got), len(tags))
+	}
+
+	if _, err := NewGitHubProvider(srv.URL, "token").ListTags("org/otra"); err != ErrNotFound {
+		t.Fatalf("repositorio inexistente: %v", err)
+	}
+}
+
+func TestGitLabListTagsFollowsNextPage(t *testing.T) {
+	tags := testTags(230)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.EscapedPath() != "/api/v4/projects/grupo%2Flib/repository/tags" {
+			http.NotFound(w, r)
+			return
+		}
+		items, more := pageOf(tags, r)
+		if more {
+			page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+			w.Header().Set("X-Next-Page", strconv.Itoa(page+1))
+		} else {
+			w.Header().Set("X-Next-Page", "")
+		}
+		json.NewEncoder(w).Encode(items)
+	}))
+	defer srv.Close()
+
+	got, err := NewGitLabProvider(srv.URL, "token").ListTags("grupo/lib")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, tags) {
+		t.Fatalf("se obtuvieron %d tags, se esperaban %d", len(got), len(tags))
+	}
+}
@@ -0,0 +1,93 @@
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/reitmas32/next/internal/config"
+)
+
+// Store guarda respuestas de los proveedores en ~/.next/cache/<nombre>, un
+// archivo por clave. Los errores de escritura se ignoran: el cache es opcional.
+type Store struct {
+	Dir string
+}
+
+// entry es el contenido de un archivo del cache
+type entry struct {
+	Key      string    `json:"key"`
+	StoredAt time.Time `json:"stored_at"`
+	Data     []byte    `json:"data"`
+}
+
+// Open abre (o crea) el cache con el nombre indicado
+func Open(name string) (*Store, error) {
+	dir, err := config.GetConfigDir()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Store{Dir: filepath.Join(dir, "cache", name)}, nil
+}
+
+// path retorna el archivo de una clave
+func (s *Store) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(s.Dir, hex.EncodeToString(sum[:])+".json")
+}
+
+// Get retorna el valor de una clave si existe y tiene menos de ttl
+func (s *Store) Get(key string, ttl time.Duration) ([]byte, bool) {
+	data, storedAt, ok := s.Lookup(key)
+	if !ok || time.Since(storedAt) > ttl {
+		return nil, false
+	}
+	return data, true
+}
+
+// Lookup retorna el valor de una clave y cuándo se guardó, sin importar su edad
+func (s *Store) Lookup(key string) ([]byte, time.Time, bool) {
+	raw, err := os.ReadFile(s.path(key))
+	if err != nil {
+		return nil, time.Time{}, false
+	}
+
+	var e entry
+	if err := json.Unmarshal(raw, &e); err != nil || e.Key != key {
+		return nil, time.Time{}, false
+	}
+	return e.Data, e.StoredAt, true
+}
+
+// Put guarda el valor de una clave. Se escribe en un archivo temporal y se
+// renombra para que otro proceso nunca lea un archivo a medio escribir.
+func (s *Store) Put(key string, data []byte) error {
+	raw, err := json.Marshal(entry{Key: key, StoredAt: time.Now(), Data: data})
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(s.Dir, 0700); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(s.Dir, ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(raw); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), s.path(key))
+}
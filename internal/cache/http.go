@@ -0,0 +1,153 @@
+package cache
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// ErrOffline indica que una consulta no está en el cache y no se puede ir a la red
+var ErrOffline = errors.New("sin datos en el cache (modo offline)")
+
+// Transport es un http.RoundTripper que guarda las respuestas GET y HEAD de
+// las APIs de los proveedores. Una respuesta con menos de TTL se sirve sin ir
+// a la red; una más vieja se revalida con If-None-Match y, si el servidor
+// responde 304, se reutiliza (en GitHub no consume rate limit).
+//
+// La clave incluye un hash de las credenciales de la petición: después de
+// 'next login' con otro token (otros permisos) no se sirven respuestas viejas.
+type Transport struct {
+	Store *Store
+	// Account separa el cache de cada cuenta
+	Account string
+	TTL     time.Duration
+	// Offline sirve solo desde el cache, sin importar la edad de las respuestas
+	Offline bool
+	// Refresh ignora el cache y vuelve a guardar lo que responda el servidor
+	Refresh bool
+	// Base es el transporte real (nil: http.DefaultTransport)
+	Base http.RoundTripper
+}
+
+// cachedResponse es una respuesta guardada en el cache
+type cachedResponse struct {
+	Status int         `json:"status"`
+	Header http.Header `json:"header"`
+	Body   []byte      `json:"body"`
+}
+
+// RoundTrip implementa http.RoundTripper. El cliente HTTP ya agrega el
+// método y la URL a los errores.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet && req.Method != http.MethodHead {
+		if t.Offline {
+			return nil, ErrOffline
+		}
+		return t.base().RoundTrip(req)
+	}
+
+	key := "http\n" + t.Account + "\n" + credentialID(req) + "\n" + req.Method + " " + req.URL.String()
+	cached, storedAt, ok := t.lookup(key)
+
+	if t.Offline {
+		if !ok {
+			return nil, ErrOffline
+		}
+		return cached.response(req), nil
+	}
+	if ok && !t.Refresh && time.Since(storedAt) < t.TTL {
+		return cached.response(req), nil
+	}
+
+	etag := ""
+	if ok && !t.Refresh {
+		etag = cached.Header.Get("ETag")
+	}
+	if etag != "" {
+		req = req.Clone(req.Context())
+		req.Header.Set("If-None-Match", etag)
+	}
+
+	resp, err := t.base().RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusNotModified && etag != "" {
+		resp.Body.Close()
+		t.put(key, cached)
+		return cached.response(req), nil
+	}
+
+	// Solo se guardan respuestas definitivas; los errores se reintentan
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNotFound {
+		return resp, nil
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	t.put(key, &cachedResponse{Status: resp.StatusCode, Header: resp.Header, Body: body})
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	return resp, nil
+}
+
+// credentialID retorna un hash corto de las credenciales de la petición
+// (Authorization de GitHub o PRIVATE-TOKEN de GitLab); el token nunca se
+// guarda en el cache
+func credentialID(req *http.Request) string {
+	sum := sha256.Sum256([]byte(req.Header.Get("Authorization") + "\n" + req.Header.Get("PRIVATE-TOKEN")))
+	return hex.EncodeToString(sum[:8])
+}
+
+func (t *Transport) base() http.RoundTripper {
+	if t.Base != nil {
+		return t.Base
+	}
+	return http.DefaultTransport
+}
+
+// lookup lee una respuesta del cache
+func (t *Transport) lookup(key string) (*cachedResponse, time.Time, bool) {
+	data, storedAt, ok := t.Store.Lookup(key)
+	if !ok {
+		return nil, time.Time{}, false
+	}
+
+	var c cachedResponse
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, time.Time{}, false
+	}
+	return &c, storedAt, true
+}
+
+// put guarda una respuesta; el cache es opcional, así que los errores se ignoran
+func (t *Transport) put(key string, c *cachedResponse) {
+	if data, err := json.Marshal(c); err == nil {
+		_ = t.Store.Put(key, data)
+	}
+}
+
+// response arma la respuesta HTTP a partir de lo guardado
+func (c *cachedResponse) response(req *http.Request) *http.Response {
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", c.Status, http.StatusText(c.Status)),
+		StatusCode:    c.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        c.Header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(c.Body)),
+		ContentLength: int64(len(c.Body)),
+		Request:       req,
+	}
+}
@@ -0,0 +1,177 @@
+package cache
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// testAPI simula una API con ETag: responde 304 si If-None-Match coincide
+type testAPI struct {
+	server                *httptest.Server
+	requests, notModified atomic.Int32
+	body                  atomic.Value
+}
+
+func newTestAPI(t *testing.T) *testAPI {
+	t.Helper()
+	a := &testAPI{}
+	a.body.Store(`["v1.0.0"]`)
+	a.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		a.requests.Add(1)
+		if r.URL.Path == "/missing" {
+			http.NotFound(w, r)
+			return
+		}
+
+		body := a.body.Load().(string)
+		etag := `"` + body + `"`
+		if r.Header.Get("If-None-Match") == etag {
+			a.notModified.Add(1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", etag)
+		io.WriteString(w, body)
+	}))
+	t.Cleanup(a.server.Close)
+	return a
+}
+
+// get hace una petición con el token indicado y retorna status y body
+func get(t *testing.T, transport *Transport, url, token string) (int, string, error) {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		return 0, "", err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp.StatusCode, string(body), nil
+}
+
+func TestTransportServesFreshResponsesFromCache(t *testing.T) {
+	api := newTestAPI(t)
+	transport := &Transport{Store: &Store{Dir: t.TempDir()}, Account: "trabajo", TTL: time.Hour}
+
+	for i := 0; i < 2; i++ {
+		status, body, err := get(t, transport, api.server.URL+"/tags", "token-a")
+		if err != nil || status != http.StatusOK || body != `["v1.0.0"]` {
+			t.Fatalf("GET %d: %d %q %v", i, status, body, err)
+		}
+	}
+	if n := api.requests.Load(); n != 1 {
+		t.Fatalf("se hicieron %d peticiones, se esperaba 1", n)
+	}
+
+	// Los 404 también se guardan
+	for i := 0; i < 2; i++ {
+		if status, _, err := get(t, transport, api.server.URL+"/missing", "token-a"); err != nil || status != http.StatusNotFound {
+			t.Fatalf("GET /missing: %d %v", status, err)
+		}
+	}
+	if n := api.requests.Load(); n != 2 {
+		t.Fatalf("se hicieron %d peticiones, se esperaban 2", n)
+	}
+}
+
+func TestTransportRevalidatesWithETag(t *testing.T) {
+	api := newTestAPI(t)
+	transport := &Transport{Store: &Store{Dir: t.TempDir()}, Account: "trabajo", TTL: 0}
+
+	if _, _, err := get(t, transport, api.server.URL+"/tags", "token-a"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Vencido el TTL se revalida: 304 reutiliza la respuesta guardada
+	status, body, err := get(t, transport, api.server.URL+"/tags", "token-a")
+	if err != nil || status != http.StatusOK || body != `["v1.0.0"]` {
+		t.Fatalf("GET revalidado: %d %q %v", status, body, err)
+	}
+	if n := api.notModified.Load(); n != 1 {
+		t.Fatalf("el servidor respondió %d veces 304, se esperaba 1", n)
+	}
+
+	// Si el contenido cambió el servidor responde 200 y se guarda lo nuevo
+	api.body.Store(`["v1.0.0","v1.1.0"]`)
+	if _, body, _ := get(t, transport, api.server.URL+"/tags", "token-a"); body != `["v1.0.0","v1.1.0"]` {
+		t.Fatalf("GET con contenido nuevo: %q", body)
+	}
+}
+
+func TestTransportSeparatesTokens(t *testing.T) {
+	api := newTestAPI(t)
+	store := &Store{Dir: t.TempDir()}
+	transport := &Transport{Store: store, Account: "trabajo", TTL: time.Hour}
+
+	if _, _, err := get(t, transport, api.server.URL+"/tags", "token-a"); err != nil {
+		t.Fatal(err)
+	}
+	// Otro token de la misma cuenta (ej: después de 'next login') no usa el cache
+	if _, _, err := get(t, transport, api.server.URL+"/tags", "token-b"); err != nil {
+		t.Fatal(err)
+	}
+	if n := api.requests.Load(); n != 2 {
+		t.Fatalf("se hicieron %d peticiones, se esperaban 2", n)
+	}
+
+	// El token no se guarda en el cache
+	files, err := filepath.Glob(filepath.Join(store.Dir, "*.json"))
+	if err != nil || len(files) != 2 {
+		t.Fatalf("archivos del cache: %v, %v", files, err)
+	}
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if strings.Contains(string(data), "token-") {
+			t.Fatal("el cache contiene el token")
+		}
+	}
+}
+
+func TestTransportOffline(t *testing.T) {
+	api := newTestAPI(t)
+	store := &Store{Dir: t.TempDir()}
+
+	online := &Transport{Store: store, Account: "trabajo", TTL: 0}
+	if _, _, err := get(t, online, api.server.URL+"/tags", "token-a"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Offline sirve lo guardado aunque esté vencido, sin ir a la red
+	offline := &Transport{Store: store, Account: "trabajo", TTL: 0, Offline: true}
+	status, body, err := get(t, offline, api.server.URL+"/tags", "token-a")
+	if err != nil || status != http.StatusOK || body != `["v1.0.0"]` {
+		t.Fatalf("GET offline: %d %q %v", status, body, err)
+	}
+	if _, _, err := get(t, offline, api.server.URL+"/otra", "token-a"); !errors.Is(err, ErrOffline) {
+		t.Fatalf("GET offline sin cache: %v", err)
+	}
+
+	req, _ := http.NewRequest(http.MethodPost, api.server.URL+"/tags", nil)
+	if _, err := offline.RoundTrip(req); !errors.Is(err, ErrOffline) {
+		t.Fatalf("POST offline: %v", err)
+	}
+
+	if n := api.requests.Load(); n != 1 {
+		t.Fatalf("se hicieron %d peticiones, se esperaba 1", n)
+	}
+}
@@ -22,12 +22,22 @@ var (
 
 // getConfigPath retorna la ruta completa del archivo de configuración
 func getConfigPath() (string, error) {
+	dir, err := GetConfigDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, configFile), nil
+}
+
+// GetConfigDir retorna el directorio de configuración (~/.next)
+func GetConfigDir() (string, error) {
 	homeDir, err := os.UserHomeDir()
 	if err != nil {
 		return "", fmt.Errorf("no se pudo obtener directorio home: %w", err)
 	}
 
-	return filepath.Join(homeDir, configDir, configFile), nil
+	return filepath.Join(homeDir, configDir), nil
 }
 
 // getEncryptionKey obtiene o crea la llave de encriptación
@@ -115,6 +125,7 @@ func (c *Config) Save() error {
 	// Crear copia para encriptar tokens
 	configToSave := &Config{
 		Accounts: make([]Account, len(c.Accounts)),
+		CacheTTL: c.CacheTTL,
 	}
 
 	for i, acc := range c.Accounts {
@@ -13,6 +13,9 @@ type Account struct {
 // Config representa la configuración completa del CLI
 type Config struct {
 	Accounts []Account `json:"accounts"`
+	// CacheTTL es la vigencia de las consultas guardadas en el cache de la
+	// API (duración de Go, ej: "10m"; vacío: el valor por defecto)
+	CacheTTL string `json:"cache_ttl,omitempty"`
 }
 
 // NewConfig crea una nueva configuración vacía
@@ -36,3 +39,12 @@ func (a *Account) HasOwner(owner string) bool {
 func (a *Account) IsWildcard() bool {
 	return len(a.Owners) == 0
 }
+
+// GitUsername retorna el usuario que acompaña al token en autenticación HTTP
+// GitHub acepta cualquier usuario con un PAT; GitLab requiere "oauth2"
+func (a *Account) GitUsername() string {
+	if a.Provider == "github" {
+		return "x-access-token"
+	}
+	return "oauth2"
+}
@@ -0,0 +1,236 @@
+package credentials
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"github.com/reitmas32/next/internal/config"
+	"github.com/reitmas32/next/internal/git"
+)
+
+const manifestFile = "credentials.json"
+
+// Tipos de artefactos que next escribe fuera de ~/.next
+const (
+	KindGitConfig = "gitconfig"
+	KindNetrc     = "netrc"
+)
+
+// Artifact representa una clave de git config o una entrada de .netrc escrita por next
+type Artifact struct {
+	Kind string `json:"kind"`
+	// Key es la clave de git config o la máquina de .netrc
+	Key string `json:"key"`
+	// File es el archivo de git config (vacío para ~/.gitconfig)
+	File string `json:"file,omitempty"`
+	// Login solo aplica a entradas de .netrc
+	Login string `json:"login,omitempty"`
+	// Previous son los valores que tenía la clave de git config antes de que
+	// next la escribiera (ej: el helper de 'gh auth setup-git'); se restauran
+	// al eliminar el artefacto
+	Previous []string `json:"previous,omitempty"`
+	// Accounts son las cuentas que dependen del artefacto
+	Accounts []string `json:"accounts"`
+}
+
+// Manifest registra todos los artefactos de credenciales escritos por 'next check'
+type Manifest struct {
+	Artifacts []Artifact `json:"artifacts"`
+}
+
+// getManifestPath retorna la ruta de ~/.next/credentials.json
+func getManifestPath() (string, error) {
+	dir, err := config.GetConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, manifestFile), nil
+}
+
+// LoadManifest carga el manifiesto o retorna uno vacío
+func LoadManifest() (*Manifest, error) {
+	manifestPath, err := getManifestPath()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(manifestPath)
+	if os.IsNotExist(err) {
+		return &Manifest{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error al leer manifiesto de credenciales: %w", err)
+	}
+
+	var m Manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("error al parsear manifiesto de credenciales: %w", err)
+	}
+
+	return &m, nil
+}
+
+// Save guarda el manifiesto en ~/.next
+func (m *Manifest) Save() error {
+	manifestPath, err := getManifestPath()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(manifestPath), 0700); err != nil {
+		return fmt.Errorf("error al crear directorio de configuración: %w", err)
+	}
+
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(manifestPath, data, 0600)
+}
+
+// Record registra un artefacto asociado a una cuenta (sin duplicados)
+func (m *Manifest) Record(artifact Artifact, account string) {
+	for i := range m.Artifacts {
+		a := &m.Artifacts[i]
+		if a.Kind != artifact.Kind || a.Key != artifact.Key || a.File != artifact.File || a.Login != artifact.Login {
+			continue
+		}
+		// Previous se conserva de la primera vez que next escribió la clave:
+		// los valores actuales pueden ser de una ejecución anterior de next
+		for _, acc := range a.Accounts {
+			if acc == account {
+				return
+			}
+		}
+		a.Accounts = append(a.Accounts, account)
+		return
+	}
+
+	artifact.Accounts = []string{account}
+	m.Artifacts = append(m.Artifacts, artifact)
+}
+
+// Clean elimina todos los artefactos registrados y vacía el manifiesto
+func (m *Manifest) Clean() ([]Artifact, error) {
+	var removed, failed []Artifact
+	var firstErr error
+
+	for _, a := range m.Artifacts {
+		if err := removeArtifact(a); err != nil {
+			failed = append(failed, a)
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		removed = append(removed, a)
+	}
+
+	m.Artifacts = failed
+	return removed, firstErr
+}
+
+// ReleaseAccount desasocia una cuenta de sus artefactos y elimina los que
+// ya no usa ninguna otra cuenta
+func (m *Manifest) ReleaseAccount(account string) ([]Artifact, error) {
+	var kept, removed []Artifact
+	var firstErr error
+
+	for _, a := range m.Artifacts {
+		var accounts []string
+		for _, acc := range a.Accounts {
+			if acc != account {
+				accounts = append(accounts, acc)
+			}
+		}
+
+		if len(accounts) > 0 || len(a.Accounts) == 0 {
+			a.Accounts = accounts
+			kept = append(kept, a)
+			continue
+		}
+
+		if err := removeArtifact(a); err != nil {
+			kept = append(kept, a)
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		removed = append(removed, a)
+	}
+
+	m.Artifacts = kept
+	return removed, firstErr
+}
+
+// RemoveTokenCopies elimina copias del token que no están en el manifiesto:
+// líneas de .netrc y reglas url.<token>@dominio.insteadOf de versiones anteriores.
+// Retorna la cantidad de entradas eliminadas.
+func RemoveTokenCopies(token string) (int, error) {
+	if token == "" {
+		return 0, nil
+	}
+
+	removed, err := RemoveNetrcEntries(func(machine, login, password string) bool {
+		return password == token || login == token
+	})
+	if err != nil {
+		return removed, err
+	}
+
+	pattern := `^url\.https?://[^@]*` + regexp.QuoteMeta(token) + `[^@]*@.*\.insteadof$`
+	entries, err := git.GetConfigRegexp("", pattern)
+	if err != nil {
+		return removed, err
+	}
+
+	for key := range entries {
+		if err := git.UnsetConfig("", key); err != nil {
+			return removed, err
+		}
+		removed++
+	}
+
+	return removed, nil
+}
+
+// removeArtifact elimina un artefacto del sistema. Las claves de git config
+// vuelven a los valores que tenían antes de que next las escribiera.
+func removeArtifact(a Artifact) error {
+	switch a.Kind {
+	case KindGitConfig:
+		if a.File != "" {
+			if _, err := os.Stat(a.File); os.IsNotExist(err) {
+				// El repositorio ya no existe
+				return nil
+			}
+		}
+		if err := git.UnsetConfig(a.File, a.Key); err != nil {
+			return err
+		}
+		for i, value := range a.Previous {
+			var err error
+			if i == 0 {
+				err = git.SetConfig(a.File, a.Key, value)
+			} else {
+				err = git.AddConfig(a.File, a.Key, value)
+			}
+			if err != nil {
+				return fmt.Errorf("error al restaurar %s: %w", a.Key, err)
+			}
+		}
+		return git.RemoveSectionIfEmpty(a.File, a.Key)
+	case KindNetrc:
+		_, err := RemoveNetrcEntries(func(machine, login, password string) bool {
+			return machine == a.Key && login == a.Login
+		})
+		return err
+	default:
+		return fmt.Errorf("tipo de artefacto desconocido: %s", a.Kind)
+	}
+}
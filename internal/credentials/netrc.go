@@ -0,0 +1,112 @@
+package credentials
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// getNetrcPath retorna la ruta de ~/.netrc (o $NETRC si está definida)
+func getNetrcPath() (string, error) {
+	if path := os.Getenv("NETRC"); path != "" {
+		return path, nil
+	}
+
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(homeDir, ".netrc"), nil
+}
+
+// AddNetrcEntry agrega una línea "machine ... login ... password ..." a .netrc.
+// Retorna false si ya existía una entrada para la máquina (no se modifica).
+func AddNetrcEntry(machine, login, password string) (bool, error) {
+	netrcPath, err := getNetrcPath()
+	if err != nil {
+		return false, err
+	}
+
+	// Leer contenido existente
+	var content string
+	if data, err := os.ReadFile(netrcPath); err == nil {
+		content = string(data)
+	}
+
+	// Verificar si ya existe entrada para este dominio
+	for _, line := range strings.Split(content, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) >= 2 && fields[0] == "machine" && fields[1] == machine {
+			return false, nil
+		}
+	}
+
+	entry := fmt.Sprintf("\nmachine %s login %s password %s\n", machine, login, password)
+
+	f, err := os.OpenFile(netrcPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+
+	if _, err := f.WriteString(entry); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// RemoveNetrcEntries elimina las líneas de .netrc que cumplen match.
+// Solo considera entradas de una línea, que es el formato que escribe next.
+// Retorna la cantidad de líneas eliminadas.
+func RemoveNetrcEntries(match func(machine, login, password string) bool) (int, error) {
+	netrcPath, err := getNetrcPath()
+	if err != nil {
+		return 0, err
+	}
+
+	data, err := os.ReadFile(netrcPath)
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	var kept []string
+	removed := 0
+	for _, line := range strings.Split(string(data), "\n") {
+		machine, login, password, ok := parseNetrcLine(line)
+		if ok && match(machine, login, password) {
+			removed++
+			continue
+		}
+		kept = append(kept, line)
+	}
+
+	if removed == 0 {
+		return 0, nil
+	}
+
+	return removed, os.WriteFile(netrcPath, []byte(strings.Join(kept, "\n")), 0600)
+}
+
+// parseNetrcLine parsea una entrada de una línea "machine X login Y password Z"
+func parseNetrcLine(line string) (machine, login, password string, ok bool) {
+	fields := strings.Fields(line)
+	if len(fields) < 6 || len(fields)%2 != 0 || fields[0] != "machine" {
+		return "", "", "", false
+	}
+
+	machine = fields[1]
+	for i := 2; i+1 < len(fields); i += 2 {
+		switch fields[i] {
+		case "login":
+			login = fields[i+1]
+		case "password":
+			password = fields[i+1]
+		}
+	}
+
+	return machine, login, password, true
+}
@@ -0,0 +1,197 @@
+package docs
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/doc"
+	"go/parser"
+	"go/token"
+	"io"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Module es la documentación de una versión de un módulo
+type Module struct {
+	Path     string
+	Version  string
+	Packages []*Package
+}
+
+// Package es la documentación de un package del módulo
+type Package struct {
+	ImportPath string
+	Module     *Module
+	Doc        *doc.Package
+	Fset       *token.FileSet
+	// Imports mapea el nombre explícito de cada import a su path; los
+	// imports sin nombre se guardan en Unnamed
+	Imports map[string]string
+	Unnamed []string
+
+	// comments son los comentarios de los archivos, para imprimir los de
+	// campos y constantes dentro de las declaraciones
+	comments []*ast.CommentGroup
+}
+
+// LoadZip extrae la documentación de todos los packages del zip de un módulo
+// (el formato de GOPROXY: archivos bajo <módulo>@<versión>/)
+func LoadZip(module, version string, data []byte) (*Module, error) {
+	reader, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("zip inválido: %w", err)
+	}
+
+	prefix := module + "@" + version + "/"
+	sources := make(map[string]map[string][]byte)
+
+	for _, f := range reader.File {
+		name := strings.TrimPrefix(f.Name, prefix)
+		if name == f.Name || !strings.HasSuffix(name, ".go") || skipDir(path.Dir(name)) {
+			continue
+		}
+
+		rc, err := f.Open()
+		if err != nil {
+			return nil, err
+		}
+		src, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			return nil, err
+		}
+
+		dir := path.Dir(name)
+		if sources[dir] == nil {
+			sources[dir] = make(map[string][]byte)
+		}
+		sources[dir][name] = src
+	}
+
+	m := &Module{Path: module, Version: version}
+	for dir, files := range sources {
+		importPath := module
+		if dir != "." {
+			importPath = module + "/" + dir
+		}
+
+		pkg, err := loadPackage(importPath, files)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", importPath, err)
+		}
+		if pkg != nil {
+			pkg.Module = m
+			m.Packages = append(m.Packages, pkg)
+		}
+	}
+
+	sort.Slice(m.Packages, func(i, j int) bool {
+		return m.Packages[i].ImportPath < m.Packages[j].ImportPath
+	})
+	return m, nil
+}
+
+// skipDir indica si un directorio no contiene packages del módulo
+func skipDir(dir string) bool {
+	if dir == "." {
+		return false
+	}
+	for _, elem := range strings.Split(dir, "/") {
+		if elem == "testdata" || elem == "vendor" || strings.HasPrefix(elem, ".") || strings.HasPrefix(elem, "_") {
+			return true
+		}
+	}
+	return false
+}
+
+// loadPackage parsea los archivos de un directorio. Los archivos _test.go se
+// incluyen para extraer los ejemplos. Retorna nil si no hay un package con
+// archivos que no sean de test (ej: solo package main con build tags ignore).
+func loadPackage(importPath string, files map[string][]byte) (*Package, error) {
+	fset := token.NewFileSet()
+
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var parsed []*ast.File
+	count := make(map[string]int)
+	for _, name := range names {
+		f, err := parser.ParseFile(fset, name, files[name], parser.ParseComments)
+		if err != nil {
+			return nil, err
+		}
+		parsed = append(parsed, f)
+		if !strings.HasSuffix(name, "_test.go") && f.Name.Name != "documentation" {
+			count[f.Name.Name]++
+		}
+	}
+
+	// El package del directorio es el más común entre los archivos que no son de test
+	pkgName := ""
+	for name, n := range count {
+		if pkgName == "" || n > count[pkgName] || (n == count[pkgName] && name < pkgName) {
+			pkgName = name
+		}
+	}
+	if pkgName == "" {
+		return nil, nil
+	}
+
+	pkg := &Package{ImportPath: importPath, Fset: fset, Imports: make(map[string]string)}
+	unnamed := make(map[string]bool)
+
+	var selected []*ast.File
+	for i, f := range parsed {
+		isTest := strings.HasSuffix(names[i], "_test.go")
+		if f.Name.Name != pkgName && !(isTest && f.Name.Name == pkgName+"_test") {
+			continue
+		}
+		selected = append(selected, f)
+		if isTest {
+			continue
+		}
+		pkg.comments = append(pkg.comments, f.Comments...)
+
+		for _, imp := range f.Imports {
+			importPath, err := strconv.Unquote(imp.Path.Value)
+			if err != nil {
+				continue
+			}
+			switch {
+			case imp.Name == nil:
+				unnamed[importPath] = true
+			case imp.Name.Name != "_" && imp.Name.Name != ".":
+				pkg.Imports[imp.Name.Name] = importPath
+			}
+		}
+	}
+
+	for p := range unnamed {
+		pkg.Unnamed = append(pkg.Unnamed, p)
+	}
+	sort.Strings(pkg.Unnamed)
+
+	d, err := doc.NewFromFiles(fset, selected, importPath)
+	if err != nil {
+		return nil, err
+	}
+	pkg.Doc = d
+	return pkg, nil
+}
+
+// Dir retorna el directorio del package dentro del módulo ("" para la raíz)
+func (p *Package) Dir() string {
+	return strings.TrimPrefix(strings.TrimPrefix(p.ImportPath, p.Module.Path), "/")
+}
+
+// IsCommand indica si el package es un programa (package main)
+func (p *Package) IsCommand() bool {
+	return p.Doc.Name == "main"
+}
@@ -0,0 +1,425 @@
+package docs
+
+import (
+	"bytes"
+	"go/ast"
+	"go/doc"
+	"go/doc/comment"
+	"go/printer"
+	"go/scanner"
+	"go/token"
+	"html"
+	"html/template"
+	"io"
+	"strings"
+)
+
+// renderer genera el HTML de la página de un package
+type renderer struct {
+	site *Site
+	pkg  *Package
+	// imports mapea el nombre con que se usa cada import a su path
+	imports map[string]string
+	// declared son los identificadores de nivel superior del package
+	declared map[string]bool
+}
+
+func newRenderer(site *Site, pkg *Package) *renderer {
+	r := &renderer{
+		site:     site,
+		pkg:      pkg,
+		imports:  make(map[string]string),
+		declared: make(map[string]bool),
+	}
+
+	for _, p := range pkg.Unnamed {
+		r.imports[site.packageName(p)] = p
+	}
+	for name, p := range pkg.Imports {
+		r.imports[name] = p
+	}
+
+	d := pkg.Doc
+	for _, v := range append(append([]*doc.Value{}, d.Consts...), d.Vars...) {
+		for _, name := range v.Names {
+			r.declared[name] = true
+		}
+	}
+	for _, f := range d.Funcs {
+		r.declared[f.Name] = true
+	}
+	for _, t := range d.Types {
+		r.declared[t.Name] = true
+		for _, v := range append(append([]*doc.Value{}, t.Consts...), t.Vars...) {
+			for _, name := range v.Names {
+				r.declared[name] = true
+			}
+		}
+		for _, f := range t.Funcs {
+			r.declared[f.Name] = true
+		}
+	}
+	return r
+}
+
+// docHTML convierte un comentario de documentación a HTML. Los links [Nombre]
+// y [pkg.Nombre] apuntan al sitio o a la documentación externa.
+func (r *renderer) docHTML(text string) template.HTML {
+	if text == "" {
+		return ""
+	}
+
+	p := r.pkg.Doc.Printer()
+	p.HeadingLevel = 3
+	p.DocLinkURL = func(link *comment.DocLink) string {
+		anchor := link.Name
+		if link.Recv != "" {
+			anchor = link.Recv + "." + link.Name
+		}
+		if link.ImportPath == "" || link.ImportPath == r.pkg.ImportPath {
+			return "#" + anchor
+		}
+		return r.site.packageURL(r.pkg.ImportPath, link.ImportPath, anchor)
+	}
+
+	return template.HTML(p.HTML(r.pkg.Doc.Parser().Parse(text)))
+}
+
+// code imprime una declaración con sus comentarios internos y agrega links
+// a los identificadores del package y de los imports
+func (r *renderer) code(node ast.Node) template.HTML {
+	var comments []*ast.CommentGroup
+	for _, c := range r.pkg.comments {
+		if c.Pos() >= node.Pos() && c.End() <= node.End() {
+			comments = append(comments, c)
+		}
+	}
+	return r.linkify(printNode(r.pkg.Fset, &printer.CommentedNode{Node: node, Comments: comments}))
+}
+
+// exampleCode imprime el código de un ejemplo sin las llaves del bloque ni
+// el comentario de salida
+func (r *renderer) exampleCode(ex *doc.Example) template.HTML {
+	src := printNode(r.pkg.Fset, &printer.CommentedNode{Node: ex.Code, Comments: ex.Comments})
+
+	if _, ok := ex.Code.(*ast.BlockStmt); ok {
+		src = strings.TrimSuffix(strings.TrimPrefix(src, "{\n"), "\n}")
+
+		var lines []string
+		for _, line := range strings.Split(src, "\n") {
+			trimmed := strings.TrimSpace(line)
+			if strings.HasPrefix(trimmed, "// Output:") || strings.HasPrefix(trimmed, "// Unordered output:") {
+				break
+			}
+			lines = append(lines, strings.TrimPrefix(line, "\t"))
+		}
+		src = strings.TrimRight(strings.Join(lines, "\n"), "\n")
+	}
+	return r.linkify(src)
+}
+
+// printNode formatea un nodo del AST como gofmt
+func printNode(fset *token.FileSet, node any) string {
+	var buf bytes.Buffer
+	cfg := printer.Config{Mode: printer.UseSpaces | printer.TabIndent, Tabwidth: 4}
+	if err := cfg.Fprint(&buf, fset, node); err != nil {
+		return err.Error()
+	}
+	return buf.String()
+}
+
+// linkify escapa el código y agrega links: Nombre → declaración del package,
+// pkg.Nombre → package importado (del sitio o externo)
+func (r *renderer) linkify(src string) template.HTML {
+	type tok struct {
+		offset int
+		tok    token.Token
+		lit    string
+	}
+
+	fset := token.NewFileSet()
+	file := fset.AddFile("", fset.Base(), len(src))
+	var s scanner.Scanner
+	s.Init(file, []byte(src), func(token.Position, string) {}, scanner.ScanComments)
+
+	var toks []tok
+	for {
+		pos, t, lit := s.Scan()
+		if t == token.EOF {
+			break
+		}
+		if t == token.IDENT {
+			toks = append(toks, tok{offset: file.Offset(pos), tok: t, lit: lit})
+		} else {
+			toks = append(toks, tok{offset: file.Offset(pos), tok: t})
+		}
+	}
+
+	var b strings.Builder
+	last := 0
+	link := func(start, end int, url string) {
+		b.WriteString(html.EscapeString(src[last:start]))
+		b.WriteString(`<a href="` + html.EscapeString(url) + `">` + html.EscapeString(src[start:end]) + `</a>`)
+		last = end
+	}
+
+	for i := 0; i < len(toks); i++ {
+		t := toks[i]
+		if t.tok != token.IDENT {
+			continue
+		}
+
+		// pkg.Nombre de un import
+		if importPath, ok := r.imports[t.lit]; ok && i+2 < len(toks) && toks[i+1].tok == token.PERIOD && toks[i+2].tok == token.IDENT {
+			sel := toks[i+2]
+			if url := r.site.packageURL(r.pkg.ImportPath, importPath, sel.lit); url != "" {
+				link(t.offset, sel.offset+len(sel.lit), url)
+			}
+			i += 2
+			continue
+		}
+
+		// Selectores como x.Nombre (campos o métodos) no son del package
+		if i > 0 && toks[i-1].tok == token.PERIOD {
+			continue
+		}
+		if r.declared[t.lit] && ast.IsExported(t.lit) {
+			link(t.offset, t.offset+len(t.lit), "#"+t.lit)
+		}
+	}
+	b.WriteString(html.EscapeString(src[last:]))
+
+	return template.HTML(b.String())
+}
+
+// Datos de las plantillas
+
+type pageData struct {
+	Title      string
+	Root       string
+	Breadcrumb []linkData
+	Index      *indexData
+	Module     *moduleData
+	Package    *packageData
+}
+
+type linkData struct {
+	Name     string
+	URL      string
+	Synopsis string
+	Version  string
+}
+
+type declData struct {
+	ID   string
+	Code template.HTML
+	Doc  template.HTML
+}
+
+type funcData struct {
+	ID        string
+	Name      string
+	Signature string
+	Code      template.HTML
+	Doc       template.HTML
+	Examples  []exampleData
+}
+
+type typeData struct {
+	ID       string
+	Name     string
+	Code     template.HTML
+	Doc      template.HTML
+	Consts   []declData
+	Vars     []declData
+	Funcs    []funcData
+	Methods  []funcData
+	Examples []exampleData
+}
+
+type exampleData struct {
+	ID     string
+	Title  string
+	Doc    template.HTML
+	Code   template.HTML
+	Output string
+}
+
+type packageData struct {
+	Name        string
+	ImportPath  string
+	Module      string
+	Version     string
+	IsCommand   bool
+	Doc         template.HTML
+	Examples    []exampleData
+	Consts      []declData
+	Vars        []declData
+	Funcs       []funcData
+	Types       []typeData
+	Subpackages []linkData
+}
+
+type moduleData struct {
+	Path     string
+	Version  string
+	Packages []linkData
+}
+
+type indexData struct {
+	Modules []moduleData
+}
+
+// renderIndex genera la página de inicio con todos los módulos
+func (s *Site) renderIndex(w io.Writer) error {
+	data := &indexData{}
+	for _, m := range s.Modules {
+		data.Modules = append(data.Modules, s.moduleData("", m))
+	}
+	return pageTemplate.Execute(w, pageData{Title: "Documentación", Root: "./", Index: data})
+}
+
+// renderModule genera la página de un módulo sin package en la raíz
+func (s *Site) renderModule(w io.Writer, m *Module) error {
+	data := s.moduleData(m.Path, m)
+	return pageTemplate.Execute(w, pageData{
+		Title:      m.Path,
+		Root:       relURL(m.Path, ""),
+		Breadcrumb: []linkData{{Name: m.Path + "@" + m.Version}},
+		Module:     &data,
+	})
+}
+
+// moduleData lista los packages de un módulo con links relativos a la página from
+func (s *Site) moduleData(from string, m *Module) moduleData {
+	data := moduleData{Path: m.Path, Version: m.Version}
+	for _, p := range m.Packages {
+		name := p.Dir()
+		if name == "" {
+			name = "."
+		}
+		data.Packages = append(data.Packages, linkData{
+			Name:     name,
+			URL:      relURL(from, p.ImportPath),
+			Synopsis: p.Doc.Synopsis(p.Doc.Doc),
+		})
+	}
+	return data
+}
+
+// renderPackage genera la página de un package
+func (s *Site) renderPackage(w io.Writer, p *Package) error {
+	r := newRenderer(s, p)
+	d := p.Doc
+
+	data := &packageData{
+		Name:       d.Name,
+		ImportPath: p.ImportPath,
+		Module:     p.Module.Path,
+		Version:    p.Module.Version,
+		IsCommand:  p.IsCommand(),
+		Doc:        r.docHTML(d.Doc),
+		Examples:   r.examples(d.Examples),
+		Consts:     r.values(d.Consts),
+		Vars:       r.values(d.Vars),
+		Funcs:      r.funcs(d.Funcs, ""),
+	}
+
+	for _, t := range d.Types {
+		data.Types = append(data.Types, typeData{
+			ID:       t.Name,
+			Name:     t.Name,
+			Code:     r.code(t.Decl),
+			Doc:      r.docHTML(t.Doc),
+			Consts:   r.values(t.Consts),
+			Vars:     r.values(t.Vars),
+			Funcs:    r.funcs(t.Funcs, ""),
+			Methods:  r.funcs(t.Methods, t.Name),
+			Examples: r.examples(t.Examples),
+		})
+	}
+
+	// Packages en subdirectorios (de cualquier módulo del sitio)
+	for _, m := range s.Modules {
+		for _, sub := range m.Packages {
+			if strings.HasPrefix(sub.ImportPath, p.ImportPath+"/") {
+				data.Subpackages = append(data.Subpackages, linkData{
+					Name:     strings.TrimPrefix(sub.ImportPath, p.ImportPath+"/"),
+					URL:      relURL(p.ImportPath, sub.ImportPath),
+					Synopsis: sub.Doc.Synopsis(sub.Doc.Doc),
+					Version:  m.Version,
+				})
+			}
+		}
+	}
+
+	breadcrumb := []linkData{{Name: p.Module.Path + "@" + p.Module.Version, URL: relURL(p.ImportPath, p.Module.Path)}}
+	if p.ImportPath != p.Module.Path {
+		breadcrumb = append(breadcrumb, linkData{Name: p.Dir()})
+	}
+
+	return pageTemplate.Execute(w, pageData{
+		Title:      p.ImportPath,
+		Root:       relURL(p.ImportPath, ""),
+		Breadcrumb: breadcrumb,
+		Package:    data,
+	})
+}
+
+// values convierte constantes o variables
+func (r *renderer) values(values []*doc.Value) []declData {
+	var result []declData
+	for _, v := range values {
+		id := ""
+		if len(v.Names) > 0 {
+			id = v.Names[0]
+		}
+		result = append(result, declData{ID: id, Code: r.code(v.Decl), Doc: r.docHTML(v.Doc)})
+	}
+	return result
+}
+
+// funcs convierte funciones o métodos (recv es el tipo para los métodos)
+func (r *renderer) funcs(funcs []*doc.Func, recv string) []funcData {
+	var result []funcData
+	for _, f := range funcs {
+		id := f.Name
+		if recv != "" {
+			id = recv + "." + f.Name
+		}
+		result = append(result, funcData{
+			ID:        id,
+			Name:      f.Name,
+			Signature: printNode(r.pkg.Fset, f.Decl),
+			Code:      r.code(f.Decl),
+			Doc:       r.docHTML(f.Doc),
+			Examples:  r.examples(f.Examples),
+		})
+	}
+	return result
+}
+
+// examples convierte los ejemplos de un package, función o tipo
+func (r *renderer) examples(examples []*doc.Example) []exampleData {
+	var result []exampleData
+	for _, ex := range examples {
+		title := "Ejemplo"
+		if ex.Suffix != "" {
+			title += " (" + ex.Suffix + ")"
+		}
+
+		id := "example"
+		if ex.Name != "" {
+			id += "-" + ex.Name
+		}
+
+		result = append(result, exampleData{
+			ID:     id,
+			Title:  title,
+			Doc:    r.docHTML(ex.Doc),
+			Code:   r.exampleCode(ex),
+			Output: ex.Output,
+		})
+	}
+	return result
+}
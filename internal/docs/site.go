@@ -0,0 +1,182 @@
+package docs
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Site es un sitio HTML con la documentación de varios módulos. Los links
+// entre packages del sitio son relativos, así que el mismo HTML sirve para
+// el servidor local y para la exportación estática.
+type Site struct {
+	Modules []*Module
+	// External retorna la URL de la documentación de un package que no está
+	// en el sitio (vacía: sin link)
+	External func(importPath string) string
+
+	packages map[string]*Package
+	modules  map[string]*Module
+}
+
+// NewSite arma el sitio con los módulos cargados
+func NewSite(modules []*Module, external func(importPath string) string) *Site {
+	s := &Site{
+		Modules:  modules,
+		External: external,
+		packages: make(map[string]*Package),
+		modules:  make(map[string]*Module),
+	}
+
+	sort.Slice(s.Modules, func(i, j int) bool {
+		return s.Modules[i].Path < s.Modules[j].Path
+	})
+	for _, m := range s.Modules {
+		s.modules[m.Path] = m
+		for _, p := range m.Packages {
+			s.packages[p.ImportPath] = p
+		}
+	}
+	return s
+}
+
+// Pages retorna el path de todas las páginas del sitio ("" es el índice)
+func (s *Site) Pages() []string {
+	pages := []string{""}
+	for _, m := range s.Modules {
+		if s.packages[m.Path] == nil {
+			pages = append(pages, m.Path)
+		}
+		for _, p := range m.Packages {
+			pages = append(pages, p.ImportPath)
+		}
+	}
+	return pages
+}
+
+// Render genera el HTML de una página (path sin barras inicial ni final)
+func (s *Site) Render(page string) ([]byte, bool) {
+	var buf bytes.Buffer
+	var err error
+
+	switch {
+	case page == "":
+		err = s.renderIndex(&buf)
+	case s.packages[page] != nil:
+		err = s.renderPackage(&buf, s.packages[page])
+	case s.modules[page] != nil:
+		err = s.renderModule(&buf, s.modules[page])
+	default:
+		return nil, false
+	}
+
+	if err != nil {
+		return []byte(fmt.Sprintf("error al generar la página: %v", err)), true
+	}
+	return buf.Bytes(), true
+}
+
+// ServeHTTP sirve las páginas del sitio. Los paths sin barra final se
+// redirigen para que los links relativos funcionen.
+func (s *Site) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	page := strings.Trim(r.URL.Path, "/")
+
+	html, ok := s.Render(page)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	if page != "" && !strings.HasSuffix(r.URL.Path, "/") {
+		http.Redirect(w, r, r.URL.Path+"/", http.StatusMovedPermanently)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Write(html)
+}
+
+// Export escribe el sitio como archivos estáticos: <dir>/<página>/index.html
+func (s *Site) Export(dir string) (int, error) {
+	pages := s.Pages()
+	for _, page := range pages {
+		html, _ := s.Render(page)
+
+		pageDir := filepath.Join(dir, filepath.FromSlash(page))
+		if err := os.MkdirAll(pageDir, 0755); err != nil {
+			return 0, err
+		}
+		if err := os.WriteFile(filepath.Join(pageDir, "index.html"), html, 0644); err != nil {
+			return 0, err
+		}
+	}
+	return len(pages), nil
+}
+
+// packageURL retorna el link desde una página a la documentación de un
+// package (del sitio o externo), con un ancla opcional
+func (s *Site) packageURL(from, importPath, anchor string) string {
+	var url string
+	switch {
+	case s.packages[importPath] != nil:
+		url = relURL(from, importPath)
+	case s.External != nil:
+		url = s.External(importPath)
+	}
+	if url != "" && anchor != "" {
+		url += "#" + anchor
+	}
+	return url
+}
+
+// packageName retorna el nombre con el que se importa un package sin nombre explícito
+func (s *Site) packageName(importPath string) string {
+	if p := s.packages[importPath]; p != nil {
+		return p.Doc.Name
+	}
+
+	elems := strings.Split(importPath, "/")
+	name := elems[len(elems)-1]
+	// Sufijo de versión mayor: github.com/org/lib/v2 se importa como lib
+	if len(elems) > 1 && len(name) > 1 && name[0] == 'v' && strings.Trim(name[1:], "0123456789") == "" {
+		name = elems[len(elems)-2]
+	}
+	// gopkg.in/yaml.v3 se importa como yaml
+	if i := strings.Index(name, ".v"); i > 0 {
+		name = name[:i]
+	}
+	name = strings.TrimPrefix(name, "go-")
+	return strings.ReplaceAll(name, "-", "")
+}
+
+// relURL retorna el link relativo entre dos páginas del sitio
+func relURL(from, to string) string {
+	split := func(p string) []string {
+		if p == "" {
+			return nil
+		}
+		return strings.Split(p, "/")
+	}
+	f, t := split(from), split(to)
+
+	i := 0
+	for i < len(f) && i < len(t) && f[i] == t[i] {
+		i++
+	}
+
+	// "./" evita que un primer segmento como host:puerto se lea como esquema
+	var b strings.Builder
+	if len(f) == i {
+		b.WriteString("./")
+	}
+	for range f[i:] {
+		b.WriteString("../")
+	}
+	for _, elem := range t[i:] {
+		b.WriteString(elem + "/")
+	}
+	return b.String()
+}
@@ -0,0 +1,124 @@
+package docs
+
+import "html/template"
+
+// pageTemplate genera todas las páginas del sitio. Los estilos van en la
+// página para que la exportación estática no dependa de otros archivos.
+var pageTemplate = template.Must(template.New("page").Parse(`<!DOCTYPE html>
+<html lang="es">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Title}}</title>
+<style>
+body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; margin: 0; color: #1f2328; line-height: 1.5; }
+header { background: #f6f8fa; border-bottom: 1px solid #d0d7de; padding: 0.75rem 2rem; }
+header a { color: #0969da; text-decoration: none; font-weight: 600; }
+header span { color: #59636e; }
+main { max-width: 60rem; padding: 1rem 2rem 3rem; }
+a { color: #0969da; }
+pre { background: #f6f8fa; border-radius: 6px; padding: 0.75rem 1rem; overflow-x: auto; font-size: 0.85rem; line-height: 1.45; }
+code, pre { font-family: ui-monospace, SFMono-Regular, Menlo, Consolas, monospace; }
+pre a { color: inherit; text-decoration: underline dotted; }
+h2 { border-bottom: 1px solid #d0d7de; padding-bottom: 0.3rem; margin-top: 2rem; }
+h3 { margin-top: 1.75rem; }
+h3 a.anchor, h4 a.anchor { color: #59636e; text-decoration: none; margin-left: 0.3rem; visibility: hidden; }
+h3:hover a.anchor, h4:hover a.anchor { visibility: visible; }
+table { border-collapse: collapse; }
+td { padding: 0.25rem 1.5rem 0.25rem 0; vertical-align: top; }
+ul.index { list-style: none; padding-left: 0; }
+ul.index ul { list-style: none; padding-left: 1.5rem; }
+ul.index li { font-family: ui-monospace, SFMono-Regular, Menlo, Consolas, monospace; font-size: 0.85rem; }
+details.example { margin: 0.75rem 0; }
+details.example summary { cursor: pointer; color: #0969da; }
+.muted { color: #59636e; }
+</style>
+</head>
+<body>
+<header>
+<a href="{{.Root}}">Documentación</a>{{range .Breadcrumb}} <span>/</span> {{if .URL}}<a href="{{.URL}}">{{.Name}}</a>{{else}}<span>{{.Name}}</span>{{end}}{{end}}
+</header>
+<main>
+{{with .Index}}
+<h1>Módulos</h1>
+{{range .Modules}}
+<h2>{{.Path}} <span class="muted">{{.Version}}</span></h2>
+{{template "packages" .Packages}}
+{{else}}
+<p class="muted">No hay módulos cargados.</p>
+{{end}}
+{{end}}
+
+{{with .Module}}
+<h1>{{.Path}} <span class="muted">{{.Version}}</span></h1>
+{{template "packages" .Packages}}
+{{end}}
+
+{{with .Package}}
+<h1>{{if .IsCommand}}Comando{{else}}package {{.Name}}{{end}}</h1>
+<pre>import "{{.ImportPath}}"</pre>
+<p class="muted">Módulo {{.Module}} {{.Version}}</p>
+{{.Doc}}
+{{template "examples" .Examples}}
+
+{{if or .Consts .Vars .Funcs .Types}}
+<h2 id="pkg-index">Índice</h2>
+<ul class="index">
+{{if .Consts}}<li><a href="#pkg-constants">Constantes</a></li>{{end}}
+{{if .Vars}}<li><a href="#pkg-variables">Variables</a></li>{{end}}
+{{range .Funcs}}<li><a href="#{{.ID}}">{{.Signature}}</a></li>{{end}}
+{{range .Types}}<li><a href="#{{.ID}}">type {{.Name}}</a>
+{{if or .Funcs .Methods}}<ul>{{range .Funcs}}<li><a href="#{{.ID}}">{{.Signature}}</a></li>{{end}}{{range .Methods}}<li><a href="#{{.ID}}">{{.Signature}}</a></li>{{end}}</ul>{{end}}
+</li>{{end}}
+</ul>
+{{end}}
+
+{{if .Consts}}<h2 id="pkg-constants">Constantes</h2>{{range .Consts}}{{template "value" .}}{{end}}{{end}}
+{{if .Vars}}<h2 id="pkg-variables">Variables</h2>{{range .Vars}}{{template "value" .}}{{end}}{{end}}
+
+{{if .Funcs}}<h2 id="pkg-functions">Funciones</h2>{{range .Funcs}}{{template "func" .}}{{end}}{{end}}
+
+{{if .Types}}<h2 id="pkg-types">Tipos</h2>
+{{range .Types}}
+<h3 id="{{.ID}}">type {{.Name}}<a class="anchor" href="#{{.ID}}">¶</a></h3>
+{{.Doc}}
+<pre>{{.Code}}</pre>
+{{template "examples" .Examples}}
+{{range .Consts}}{{template "value" .}}{{end}}
+{{range .Vars}}{{template "value" .}}{{end}}
+{{range .Funcs}}{{template "func" .}}{{end}}
+{{range .Methods}}{{template "func" .}}{{end}}
+{{end}}
+{{end}}
+
+{{if .Subpackages}}
+<h2 id="pkg-subdirectories">Subdirectorios</h2>
+{{template "packages" .Subpackages}}
+{{end}}
+{{end}}
+</main>
+</body>
+</html>
+
+{{define "packages"}}<table>{{range .}}
+<tr><td><a href="{{.URL}}">{{.Name}}</a></td><td class="muted">{{.Synopsis}}</td></tr>{{end}}
+</table>{{end}}
+
+{{define "value"}}<div{{if .ID}} id="{{.ID}}"{{end}}>
+<pre>{{.Code}}</pre>
+{{.Doc}}
+</div>{{end}}
+
+{{define "func"}}<h4 id="{{.ID}}">func {{.Name}}<a class="anchor" href="#{{.ID}}">¶</a></h4>
+<pre>{{.Code}}</pre>
+{{.Doc}}
+{{template "examples" .Examples}}{{end}}
+
+{{define "examples"}}{{range .}}<details class="example" id="{{.ID}}">
+<summary>{{.Title}}</summary>
+{{.Doc}}
+<pre>{{.Code}}</pre>
+{{if .Output}}<p class="muted">Salida:</p>
+<pre>{{.Output}}</pre>{{end}}
+</details>{{end}}{{end}}
+`))
@@ -0,0 +1,164 @@
+package git
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Las funciones de este archivo operan sobre repositorios bare (clones con
+// --mirror) usados como cache de código fuente, sin depender del directorio actual
+
+// bareCommand crea un comando git para el repositorio bare indicado
+func bareCommand(gitDir string, args ...string) *exec.Cmd {
+	return exec.Command("git", append([]string{"--git-dir", gitDir}, args...)...)
+}
+
+// SyncBareRepo clona el repositorio como mirror si no existe o trae los tags
+// nuevos si ya existe. env se agrega al entorno (ej: GIT_CONFIG_* con credenciales).
+func SyncBareRepo(gitDir, repoURL string, env []string) error {
+	var cmd *exec.Cmd
+	if _, err := os.Stat(gitDir); os.IsNotExist(err) {
+		if err := os.MkdirAll(filepath.Dir(gitDir), 0700); err != nil {
+			return fmt.Errorf("error al crear directorio de cache: %w", err)
+		}
+		cmd = exec.Command("git", "clone", "--mirror", "--quiet", repoURL, gitDir)
+	} else {
+		// El clon --mirror ya trae refs/* (incluyendo tags) desde origin
+		cmd = bareCommand(gitDir, "fetch", "--quiet", "origin")
+	}
+
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+	cmd.Env = append(cmd.Env, env...)
+
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("error al sincronizar %s: %s", repoURL, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// BareTags lista los tags del repositorio bare
+func BareTags(gitDir string) ([]string, error) {
+	output, err := bareCommand(gitDir, "tag", "--list").Output()
+	if err != nil {
+		return nil, fmt.Errorf("error al listar tags: %w", err)
+	}
+
+	return strings.Fields(string(output)), nil
+}
+
+// BareCommitTime retorna la fecha del commit al que apunta una revisión
+func BareCommitTime(gitDir, rev string) (time.Time, error) {
+	output, err := bareCommand(gitDir, "log", "-1", "--format=%cI", rev+"^{commit}").Output()
+	if err != nil {
+		return time.Time{}, fmt.Errorf("error al leer commit de %s: %w", rev, err)
+	}
+
+	return time.Parse(time.RFC3339, strings.TrimSpace(string(output)))
+}
+
+// BareReadFile lee un archivo en una revisión; retorna false si no existe
+func BareReadFile(gitDir, rev, path string) ([]byte, bool, error) {
+	output, err := bareCommand(gitDir, "cat-file", "blob", rev+":"+path).Output()
+	if err != nil {
+		if _, ok := err.(*exec.ExitError); ok {
+			// El archivo no existe en esa revisión
+			return nil, false, nil
+		}
+		return nil, false, fmt.Errorf("error al leer %s en %s: %w", path, rev, err)
+	}
+
+	return output, true, nil
+}
+
+// BareReadObjects lee varios objetos (ej: "refs/tags/v1.0.0:go.mod" o
+// "refs/tags/v1.0.0^{commit}") con un solo 'git cat-file --batch'. Los
+// objetos que no existen no aparecen en el resultado.
+func BareReadObjects(gitDir string, objects []string) (map[string][]byte, error) {
+	result := make(map[string][]byte)
+	if len(objects) == 0 {
+		return result, nil
+	}
+
+	cmd := bareCommand(gitDir, "cat-file", "--batch")
+	cmd.Stdin = strings.NewReader(strings.Join(objects, "\n") + "\n")
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("error al leer objetos de %s: %w", gitDir, err)
+	}
+
+	// Cada respuesta es "<sha> <tipo> <tamaño>\n<contenido>\n" o "<objeto> missing\n"
+	r := bufio.NewReader(bytes.NewReader(output))
+	for _, name := range objects {
+		header, err := r.ReadString('\n')
+		if err != nil {
+			return nil, fmt.Errorf("respuesta incompleta de git cat-file: %w", err)
+		}
+
+		fields := strings.Fields(header)
+		if len(fields) != 3 {
+			continue
+		}
+		size, err := strconv.Atoi(fields[2])
+		if err != nil {
+			return nil, fmt.Errorf("respuesta inválida de git cat-file: %q", header)
+		}
+
+		data := make([]byte, size+1)
+		if _, err := io.ReadFull(r, data); err != nil {
+			return nil, fmt.Errorf("respuesta incompleta de git cat-file: %w", err)
+		}
+		result[name] = data[:size]
+	}
+
+	return result, nil
+}
+
+// archiveAttributes desactiva export-subst y export-ignore de los
+// .gitattributes del repositorio, igual que el comando go (codehost)
+const archiveAttributes = "\n* -export-subst -export-ignore\n"
+
+// BareArchive retorna un tar con el contenido de la revisión (limitado a
+// dir si no está vacío). Usa los mismos flags y atributos que el comando go
+// para que el contenido (y su hash h1:) no dependa de .gitattributes ni de
+// core.autocrlf del usuario.
+func BareArchive(gitDir, rev, dir string) ([]byte, error) {
+	attributes := filepath.Join(gitDir, "info", "attributes")
+	if err := os.MkdirAll(filepath.Dir(attributes), 0700); err != nil {
+		return nil, fmt.Errorf("error al configurar atributos de %s: %w", gitDir, err)
+	}
+	if err := os.WriteFile(attributes, []byte(archiveAttributes), 0600); err != nil {
+		return nil, fmt.Errorf("error al configurar atributos de %s: %w", gitDir, err)
+	}
+
+	args := []string{"-c", "core.autocrlf=input", "-c", "core.eol=lf", "archive", "--format=tar", rev}
+	if dir != "" {
+		args = append(args, "--", dir)
+	}
+
+	cmd := bareCommand(gitDir, args...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("error al generar archivo de %s: %s", rev, strings.TrimSpace(stderr.String()))
+	}
+
+	return output, nil
+}
+
+// RemoteExists verifica si la URL corresponde a un repositorio accesible
+func RemoteExists(repoURL string, env []string) bool {
+	cmd := exec.Command("git", "ls-remote", "--quiet", repoURL, "HEAD")
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+	cmd.Env = append(cmd.Env, env...)
+	return cmd.Run() == nil
+}
@@ -0,0 +1,119 @@
+package git
+
+import (
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// Las funciones de configuración reciben file: vacío para ~/.gitconfig
+// (--global) o la ruta de un archivo concreto (ej: .git/config del repositorio)
+
+// configArgs retorna los argumentos de 'git config' para el archivo indicado
+func configArgs(file string, args ...string) []string {
+	base := []string{"config", "--global"}
+	if file != "" {
+		base = []string{"config", "--file", file}
+	}
+	return append(base, args...)
+}
+
+// GetLocalConfigPath retorna la ruta del .git/config del repositorio actual
+func GetLocalConfigPath() (string, error) {
+	gitDir, err := GetGitCommonDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(gitDir, "config"), nil
+}
+
+// SetConfig reemplaza todos los valores de una clave
+func SetConfig(file, key, value string) error {
+	cmd := exec.Command("git", configArgs(file, "--replace-all", key, value)...)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("error al configurar %s: %s", key, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// AddConfig agrega un valor a una clave multivalor
+func AddConfig(file, key, value string) error {
+	cmd := exec.Command("git", configArgs(file, "--add", key, value)...)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("error al configurar %s: %s", key, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// UnsetConfig elimina todos los valores de una clave
+func UnsetConfig(file, key string) error {
+	cmd := exec.Command("git", configArgs(file, "--unset-all", key)...)
+	if err := cmd.Run(); err != nil {
+		// Código 5: la clave no existe; código 1: el archivo no existe
+		if exitErr, ok := err.(*exec.ExitError); ok && (exitErr.ExitCode() == 5 || exitErr.ExitCode() == 1) {
+			return nil
+		}
+		return fmt.Errorf("error al eliminar %s: %w", key, err)
+	}
+	return nil
+}
+
+// GetConfigAll retorna todos los valores de una clave (nil si no existe)
+func GetConfigAll(file, key string) ([]string, error) {
+	cmd := exec.Command("git", configArgs(file, "--get-all", key)...)
+	output, err := cmd.Output()
+	if err != nil {
+		// Código 1: la clave (o el archivo) no existe
+		if exitErr, ok := err.(*exec.ExitError); ok && exitErr.ExitCode() == 1 {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error al leer %s: %w", key, err)
+	}
+
+	return strings.Split(strings.TrimSuffix(string(output), "\n"), "\n"), nil
+}
+
+// GetConfigRegexp retorna las claves (y valores) que coinciden con el patrón
+func GetConfigRegexp(file, pattern string) (map[string]string, error) {
+	cmd := exec.Command("git", configArgs(file, "--get-regexp", pattern)...)
+	output, err := cmd.Output()
+	if err != nil {
+		// Código 1: no hay coincidencias
+		if exitErr, ok := err.(*exec.ExitError); ok && exitErr.ExitCode() == 1 {
+			return map[string]string{}, nil
+		}
+		return nil, fmt.Errorf("error al leer configuración de git: %w", err)
+	}
+
+	entries := make(map[string]string)
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		key, value, _ := strings.Cut(line, " ")
+		if key != "" {
+			entries[key] = value
+		}
+	}
+
+	return entries, nil
+}
+
+// RemoveSectionIfEmpty elimina la sección de una clave si ya no tiene valores
+// Ejemplo: "credential.https://github.com.helper" -> [credential "https://github.com"]
+func RemoveSectionIfEmpty(file, key string) error {
+	idx := strings.LastIndex(key, ".")
+	if idx <= 0 {
+		return nil
+	}
+	section := key[:idx]
+
+	entries, err := GetConfigRegexp(file, "^"+regexp.QuoteMeta(section)+`\.[^.]+$`)
+	if err != nil || len(entries) > 0 {
+		return err
+	}
+
+	// Ignorar el error si la sección ya no existe
+	_ = exec.Command("git", configArgs(file, "--remove-section", section)...).Run()
+	return nil
+}
@@ -0,0 +1,67 @@
+package git
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Credential representa los atributos del protocolo de credential helpers de git
+// (ver 'git help credential')
+type Credential struct {
+	Protocol string
+	Host     string
+	Path     string
+	Username string
+	Password string
+}
+
+// ReadCredential lee atributos key=value hasta una línea vacía o EOF
+func ReadCredential(r io.Reader) (*Credential, error) {
+	cred := &Credential{}
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if line == "" {
+			break
+		}
+
+		key, value, found := strings.Cut(line, "=")
+		if !found {
+			continue
+		}
+
+		switch key {
+		case "protocol":
+			cred.Protocol = value
+		case "host":
+			cred.Host = value
+		case "path":
+			cred.Path = value
+		case "username":
+			cred.Username = value
+		case "password":
+			cred.Password = value
+		}
+	}
+
+	return cred, scanner.Err()
+}
+
+// WriteCredential escribe usuario y contraseña en el formato del protocolo
+func WriteCredential(w io.Writer, username, password string) error {
+	_, err := fmt.Fprintf(w, "username=%s\npassword=%s\n", username, password)
+	return err
+}
+
+// ModulePath convierte host y path de la petición en un path de módulo
+// Ejemplo: "github.com" + "mi-empresa/core-lib.git" -> "github.com/mi-empresa/core-lib"
+func (c *Credential) ModulePath() string {
+	path := strings.TrimSuffix(strings.Trim(c.Path, "/"), ".git")
+	if path == "" {
+		return c.Host
+	}
+	return c.Host + "/" + path
+}
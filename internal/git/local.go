@@ -216,3 +216,24 @@ func GetBranchStatus(remote string) (*BranchStatus, error) {
 
 	return status, nil
 }
+
+// GetGitCommonDir retorna el directorio .git del repositorio actual (compartido entre worktrees)
+func GetGitCommonDir() (string, error) {
+	cmd := exec.Command("git", "rev-parse", "--path-format=absolute", "--git-common-dir")
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("no es un repositorio Git: %w", err)
+	}
+
+	return strings.TrimSpace(string(output)), nil
+}
+
+// FetchTag trae un tag del remote al repositorio local
+func FetchTag(remote, tag string) error {
+	refspec := fmt.Sprintf("refs/tags/%s:refs/tags/%s", tag, tag)
+	cmd := exec.Command("git", "fetch", "--quiet", remote, refspec)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("error al traer tag %s: %s", tag, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package git
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// Las funciones de este archivo operan sobre clones temporales en un
+// directorio explícito (ej: repositorios de otros proyectos), sin depender
+// del directorio actual. env se agrega al entorno (ej: GIT_CONFIG_* con credenciales).
+
+// workCommand crea un comando git que se ejecuta en dir
+func workCommand(dir string, env []string, args ...string) *exec.Cmd {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+	cmd.Env = append(cmd.Env, env...)
+	return cmd
+}
+
+// runWork ejecuta un comando git en dir y retorna su salida
+func runWork(dir string, env []string, args ...string) (string, error) {
+	output, err := workCommand(dir, env, args...).CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("git %s: %s", args[0], strings.TrimSpace(string(output)))
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// CloneShallow clona solo el último commit de la rama por defecto en dir
+func CloneShallow(repoURL, dir string, env []string) error {
+	_, err := runWork("", env, "clone", "--quiet", "--depth", "1", repoURL, dir)
+	return err
+}
+
+// CurrentBranchIn retorna la rama actual del clon en dir
+func CurrentBranchIn(dir string) (string, error) {
+	return runWork(dir, nil, "rev-parse", "--abbrev-ref", "HEAD")
+}
+
+// CreateBranchIn crea una rama nueva en dir y la deja como actual
+func CreateBranchIn(dir, branch string) error {
+	_, err := runWork(dir, nil, "checkout", "--quiet", "-b", branch)
+	return err
+}
+
+// HasChangesIn indica si el clon en dir tiene cambios sin commit
+func HasChangesIn(dir string) (bool, error) {
+	output, err := runWork(dir, nil, "status", "--porcelain")
+	if err != nil {
+		return false, err
+	}
+	return output != "", nil
+}
+
+// CommitAllIn agrega todos los cambios del clon en dir y hace commit
+func CommitAllIn(dir, message string) error {
+	if _, err := runWork(dir, nil, "add", "-A"); err != nil {
+		return err
+	}
+	_, err := runWork(dir, nil, "commit", "--quiet", "-m", message)
+	return err
+}
+
+// RemoteBranchIn retorna el commit de una rama en origin (vacío si no existe)
+func RemoteBranchIn(dir, branch string, env []string) (string, error) {
+	output, err := runWork(dir, env, "ls-remote", "origin", "refs/heads/"+branch)
+	if err != nil {
+		return "", err
+	}
+	sha, _, _ := strings.Cut(output, "\t")
+	return sha, nil
+}
+
+// PushBranchIn sube una rama del clon en dir a origin. Si la rama ya existe
+// en origin se reemplaza solo si sigue apuntando a expected (vacío: la rama
+// no debe existir), para no pisar commits que alguien agregó después.
+func PushBranchIn(dir, branch, expected string, env []string) error {
+	ref := "refs/heads/" + branch
+	_, err := runWork(dir, env, "push", "--quiet", "--force-with-lease="+ref+":"+expected, "origin", branch+":"+ref)
+	return err
+}
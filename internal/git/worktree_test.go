@@ -0,0 +1,107 @@
+package git
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+// testClone clona origin en un directorio hermano con un usuario de git configurado
+func testClone(t *testing.T, name string, origin string) string {
+	t.Helper()
+
+	dir := filepath.Join(filepath.Dir(origin), name)
+	if _, err := runWork("", nil, "clone", "--quiet", origin, dir); err != nil {
+		t.Fatal(err)
+	}
+	for _, args := range [][]string{
+		{"config", "user.name", "test"},
+		{"config", "user.email", "test@example.com"},
+	} {
+		if _, err := runWork(dir, nil, args...); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+// testCommit agrega un archivo y hace commit en el clon
+func testCommit(t *testing.T, dir, file string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, file), []byte(file+"\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := CommitAllIn(dir, file); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestPushBranchInReplacesOnlyExpectedBranch(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git no está instalado")
+	}
+	t.Setenv("GIT_CONFIG_GLOBAL", os.DevNull)
+	t.Setenv("GIT_CONFIG_NOSYSTEM", "1")
+
+	origin := filepath.Join(t.TempDir(), "origin.git")
+	if _, err := runWork("", nil, "init", "--quiet", "--bare", origin); err != nil {
+		t.Fatal(err)
+	}
+
+	seed := testClone(t, "seed", origin)
+	testCommit(t, seed, "README")
+	if _, err := runWork(seed, nil, "push", "--quiet", "origin", "HEAD"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Primera ejecución: la rama no existe y se crea
+	first := testClone(t, "first", origin)
+	if err := CreateBranchIn(first, "next/lib-v1.1.0"); err != nil {
+		t.Fatal(err)
+	}
+	testCommit(t, first, "go.mod")
+
+	remote, err := RemoteBranchIn(first, "next/lib-v1.1.0", nil)
+	if err != nil || remote != "" {
+		t.Fatalf("RemoteBranchIn antes del push: %q, %v", remote, err)
+	}
+	if err := PushBranchIn(first, "next/lib-v1.1.0", remote, nil); err != nil {
+		t.Fatal(err)
+	}
+	firstHead, err := runWork(first, nil, "rev-parse", "HEAD")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Segunda ejecución desde un clon nuevo: la rama existe y se reemplaza
+	second := testClone(t, "second", origin)
+	if err := CreateBranchIn(second, "next/lib-v1.1.0"); err != nil {
+		t.Fatal(err)
+	}
+	testCommit(t, second, "go.sum")
+
+	remote, err = RemoteBranchIn(second, "next/lib-v1.1.0", nil)
+	if err != nil || remote == "" {
+		t.Fatalf("RemoteBranchIn con la rama existente: %q, %v", remote, err)
+	}
+	if err := PushBranchIn(second, "next/lib-v1.1.0", "", nil); err == nil {
+		t.Fatal("el push debería fallar si la rama existe y se esperaba que no")
+	}
+	if err := PushBranchIn(second, "next/lib-v1.1.0", remote, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	head, err := runWork(second, nil, "rev-parse", "HEAD")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if remote, _ := RemoteBranchIn(second, "next/lib-v1.1.0", nil); remote != head {
+		t.Fatalf("la rama remota apunta a %s, se esperaba %s", remote, head)
+	}
+
+	// Un lease viejo no pisa commits agregados por otros
+	if err := PushBranchIn(first, "next/lib-v1.1.0", firstHead, nil); err == nil {
+		t.Fatal("el push con un lease viejo debería fallar")
+	}
+}
@@ -0,0 +1,520 @@
+package goenv
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/reitmas32/next/internal/config"
+)
+
+// Variables de entorno de Go que next administra
+const (
+	GOPRIVATE  = "GOPRIVATE"
+	GONOSUMDB  = "GONOSUMDB"
+	GONOPROXY  = "GONOPROXY"
+	GOINSECURE = "GOINSECURE"
+	GOAUTH     = "GOAUTH"
+)
+
+var managedKeys = []string{GOPRIVATE, GONOSUMDB, GONOPROXY, GOINSECURE, GOAUTH}
+
+const stateFile = "goenv.json"
+
+// State registra los patrones que next agregó a cada variable
+type State struct {
+	Added map[string][]string `json:"added"`
+}
+
+// Result contiene los valores finales y los patrones agregados en esta ejecución
+type Result struct {
+	Values map[string]string
+	Added  map[string][]string
+}
+
+// Read obtiene los valores actuales de las variables administradas con 'go env'
+func Read() (map[string]string, error) {
+	args := append([]string{"env", "-json"}, managedKeys...)
+	output, err := exec.Command("go", args...).Output()
+	if err != nil {
+		return nil, fmt.Errorf("error al ejecutar 'go env': %w", err)
+	}
+
+	values := make(map[string]string)
+	if err := json.Unmarshal(output, &values); err != nil {
+		return nil, fmt.Errorf("error al parsear 'go env': %w", err)
+	}
+
+	return values, nil
+}
+
+// Apply agrega los patrones privados (y los inseguros en GOINSECURE) sin
+// eliminar los valores que ya existían. GONOSUMDB y GONOPROXY solo se
+// modifican si el usuario los configuró explícitamente; si no, Go los
+// deriva de GOPRIVATE.
+func Apply(private, insecure []string) (*Result, error) {
+	result, changed, state, err := plan(private, insecure, true)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := write(changed); err != nil {
+		return nil, err
+	}
+
+	if err := saveState(state); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// Plan calcula los valores para exportar en el entorno de un proceso, sin
+// modificar nada: combina los patrones con los valores efectivos (incluido el
+// entorno actual). Retorna el resultado y solo las variables que cambian.
+func Plan(private, insecure []string) (*Result, map[string]string, error) {
+	result, changed, _, err := plan(private, insecure, false)
+	return result, changed, err
+}
+
+// plan combina los valores actuales con los patrones nuevos. Con persisted
+// parte del archivo de 'go env -w' (lo que Apply escribe); si no, de los
+// valores efectivos de 'go env'.
+func plan(private, insecure []string, persisted bool) (*Result, map[string]string, *State, error) {
+	file, err := fileValues()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	values := file
+	if !persisted {
+		if values, err = Read(); err != nil {
+			return nil, nil, nil, err
+		}
+	}
+
+	state, err := loadState()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	targets := map[string][]string{
+		GOPRIVATE:  private,
+		GOINSECURE: insecure,
+	}
+	for _, key := range []string{GONOSUMDB, GONOPROXY} {
+		if isExplicit(key, file, state) {
+			targets[key] = private
+		}
+	}
+
+	result := &Result{
+		Values: make(map[string]string),
+		Added:  make(map[string][]string),
+	}
+	changed := make(map[string]string)
+
+	for _, key := range managedKeys {
+		patterns, ok := targets[key]
+		if !ok || len(patterns) == 0 {
+			result.Values[key] = values[key]
+			continue
+		}
+
+		merged, owned, added := MergePatterns(SplitPatterns(values[key]), state.Added[key], patterns)
+		value := strings.Join(merged, ",")
+
+		result.Values[key] = value
+		if len(added) > 0 {
+			result.Added[key] = added
+		}
+		state.Added[key] = owned
+
+		if value != values[key] {
+			changed[key] = value
+		}
+	}
+
+	return result, changed, state, nil
+}
+
+// Undo elimina solo los patrones que next agregó y retorna los eliminados por variable
+func Undo() (map[string][]string, error) {
+	state, err := loadState()
+	if err != nil {
+		return nil, err
+	}
+
+	values, err := fileValues()
+	if err != nil {
+		return nil, err
+	}
+
+	removed := make(map[string][]string)
+	changed := make(map[string]string)
+
+	for key, owned := range state.Added {
+		if len(owned) == 0 {
+			continue
+		}
+
+		ownedSet := make(map[string]bool)
+		for _, p := range owned {
+			ownedSet[p] = true
+		}
+
+		var kept []string
+		for _, p := range splitValue(key, values[key]) {
+			if ownedSet[p] {
+				removed[key] = append(removed[key], p)
+				continue
+			}
+			kept = append(kept, p)
+		}
+
+		if len(removed[key]) > 0 {
+			changed[key] = strings.Join(kept, separator(key))
+		}
+	}
+
+	if err := write(changed); err != nil {
+		return nil, err
+	}
+
+	if err := removeState(); err != nil {
+		return nil, err
+	}
+
+	return removed, nil
+}
+
+// ApplyGOAUTH agrega un comando a GOAUTH conservando los métodos existentes
+// (por defecto "netrc"). Retorna el valor final de GOAUTH.
+func ApplyGOAUTH(command string) (string, error) {
+	value, changed, state, err := planGOAUTH(command, true)
+	if err != nil || !changed {
+		return value, err
+	}
+
+	if err := write(map[string]string{GOAUTH: value}); err != nil {
+		return "", err
+	}
+
+	state.Added[GOAUTH] = []string{command}
+	if err := saveState(state); err != nil {
+		return "", err
+	}
+
+	return value, nil
+}
+
+// PlanGOAUTH calcula el valor de GOAUTH para exportar en el entorno de un
+// proceso (a partir del valor efectivo), sin modificar nada
+func PlanGOAUTH(command string) (string, error) {
+	value, _, _, err := planGOAUTH(command, false)
+	return value, err
+}
+
+// planGOAUTH combina el valor actual de GOAUTH con el comando de next. Con
+// persisted parte del archivo de 'go env -w'; si no, del valor efectivo.
+func planGOAUTH(command string, persisted bool) (string, bool, *State, error) {
+	read := Read
+	if persisted {
+		read = fileValues
+	}
+	values, err := read()
+	if err != nil {
+		return "", false, nil, err
+	}
+
+	state, err := loadState()
+	if err != nil {
+		return "", false, nil, err
+	}
+
+	current := splitValue(GOAUTH, values[GOAUTH])
+	for _, c := range current {
+		if c == command {
+			return values[GOAUTH], false, state, nil
+		}
+	}
+
+	// Reemplazar comandos anteriores de next (ej: el binario cambió de ruta)
+	ownedSet := make(map[string]bool)
+	for _, c := range state.Added[GOAUTH] {
+		ownedSet[c] = true
+	}
+
+	var merged []string
+	for _, c := range current {
+		if !ownedSet[c] {
+			merged = append(merged, c)
+		}
+	}
+	merged = append(merged, command)
+
+	return strings.Join(merged, separator(GOAUTH)), true, state, nil
+}
+
+// SupportsGOAUTH indica si el toolchain instalado soporta GOAUTH (Go 1.24+)
+func SupportsGOAUTH() bool {
+	output, err := exec.Command("go", "env", "GOVERSION").Output()
+	if err != nil {
+		return false
+	}
+
+	version := strings.TrimPrefix(strings.TrimSpace(string(output)), "go")
+	parts := strings.SplitN(version, ".", 3)
+	if len(parts) < 2 {
+		return false
+	}
+
+	// Las versiones preliminares tienen sufijo (ej: "1.24rc1")
+	minorDigits := parts[1]
+	if i := strings.IndexFunc(minorDigits, func(r rune) bool { return r < '0' || r > '9' }); i >= 0 {
+		minorDigits = minorDigits[:i]
+	}
+
+	major, err1 := strconv.Atoi(parts[0])
+	minor, err2 := strconv.Atoi(minorDigits)
+	if err1 != nil || err2 != nil {
+		return false
+	}
+
+	return major > 1 || (major == 1 && minor >= 24)
+}
+
+// separator retorna el separador de lista de cada variable (GOAUTH usa ';')
+func separator(key string) string {
+	if key == GOAUTH {
+		return ";"
+	}
+	return ","
+}
+
+// splitValue separa el valor de una variable según su separador
+func splitValue(key, value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, separator(key)) {
+		item = strings.TrimSpace(item)
+		if item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// SplitPatterns separa una lista de patrones separados por coma
+func SplitPatterns(value string) []string {
+	var patterns []string
+	for _, p := range strings.Split(value, ",") {
+		p = strings.TrimSpace(p)
+		if p != "" {
+			patterns = append(patterns, p)
+		}
+	}
+	return patterns
+}
+
+// Covers indica si pattern cubre a target con la semántica de GOPRIVATE:
+// el patrón se compara contra un prefijo del path con el mismo número de elementos
+func Covers(pattern, target string) bool {
+	pattern = strings.TrimSuffix(pattern, "/")
+	target = strings.TrimSuffix(target, "/")
+
+	patternParts := strings.Count(pattern, "/") + 1
+	targetParts := strings.Split(target, "/")
+	if patternParts > len(targetParts) {
+		return false
+	}
+
+	prefix := strings.Join(targetParts[:patternParts], "/")
+	matched, err := path.Match(pattern, prefix)
+	return err == nil && matched
+}
+
+// MergePatterns combina los patrones existentes con los nuevos.
+// No agrega patrones ya cubiertos y reemplaza los patrones propios de next
+// que quedan cubiertos por uno nuevo; los patrones del usuario nunca se eliminan.
+// Retorna la lista final, los patrones propios de next y los agregados ahora.
+func MergePatterns(existing, owned, patterns []string) (merged, newOwned, added []string) {
+	ownedSet := make(map[string]bool)
+	for _, p := range owned {
+		ownedSet[p] = true
+	}
+
+	seen := make(map[string]bool)
+	for _, p := range existing {
+		if !seen[p] {
+			seen[p] = true
+			merged = append(merged, p)
+		}
+	}
+
+	for _, p := range patterns {
+		covered := false
+		for _, m := range merged {
+			if Covers(m, p) {
+				covered = true
+				break
+			}
+		}
+		if covered {
+			continue
+		}
+
+		// Eliminar patrones propios que el nuevo patrón cubre
+		var kept []string
+		for _, m := range merged {
+			if ownedSet[m] && Covers(p, m) {
+				delete(ownedSet, m)
+				continue
+			}
+			kept = append(kept, m)
+		}
+
+		merged = append(kept, p)
+		ownedSet[p] = true
+		added = append(added, p)
+	}
+
+	for _, m := range merged {
+		if ownedSet[m] {
+			newOwned = append(newOwned, m)
+		}
+	}
+
+	return merged, newOwned, added
+}
+
+// isExplicit determina si GONOSUMDB/GONOPROXY tienen un valor propio
+// (en el entorno o en el archivo de 'go env -w'); si no, Go los deriva de GOPRIVATE
+func isExplicit(key string, file map[string]string, state *State) bool {
+	if len(state.Added[key]) > 0 || os.Getenv(key) != "" {
+		return true
+	}
+	_, ok := file[key]
+	return ok
+}
+
+// fileValues lee las variables del archivo de 'go env -w' (GOENV). A
+// diferencia de Read no incluye el entorno del proceso: escribir a partir
+// de 'go env -json' guardaría en el archivo un GOPRIVATE exportado en el shell.
+func fileValues() (map[string]string, error) {
+	output, err := exec.Command("go", "env", "GOENV").Output()
+	if err != nil {
+		return nil, fmt.Errorf("error al ejecutar 'go env': %w", err)
+	}
+
+	values := make(map[string]string)
+	path := strings.TrimSpace(string(output))
+	if path == "" || path == "off" {
+		return values, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return values, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error al leer %s: %w", path, err)
+	}
+
+	for _, line := range strings.Split(string(data), "\n") {
+		key, value, ok := strings.Cut(strings.TrimSpace(line), "=")
+		if ok && key != "" && !strings.HasPrefix(key, "#") {
+			values[strings.TrimSpace(key)] = value
+		}
+	}
+	return values, nil
+}
+
+// write escribe las variables modificadas con 'go env -w' (o 'go env -u' si quedan vacías)
+func write(values map[string]string) error {
+	for key, value := range values {
+		var cmd *exec.Cmd
+		if value == "" {
+			cmd = exec.Command("go", "env", "-u", key)
+		} else {
+			cmd = exec.Command("go", "env", "-w", key+"="+value)
+		}
+
+		if output, err := cmd.CombinedOutput(); err != nil {
+			return fmt.Errorf("error al configurar %s: %s", key, strings.TrimSpace(string(output)))
+		}
+	}
+	return nil
+}
+
+// getStatePath retorna la ruta del archivo de estado
+func getStatePath() (string, error) {
+	dir, err := config.GetConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, stateFile), nil
+}
+
+// loadState carga el estado o retorna uno vacío
+func loadState() (*State, error) {
+	state := &State{Added: make(map[string][]string)}
+
+	statePath, err := getStatePath()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(statePath)
+	if os.IsNotExist(err) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error al leer estado de go env: %w", err)
+	}
+
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, fmt.Errorf("error al parsear estado de go env: %w", err)
+	}
+	if state.Added == nil {
+		state.Added = make(map[string][]string)
+	}
+
+	return state, nil
+}
+
+// saveState guarda el estado en ~/.next
+func saveState(state *State) error {
+	statePath, err := getStatePath()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(statePath), 0700); err != nil {
+		return fmt.Errorf("error al crear directorio de configuración: %w", err)
+	}
+
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(statePath, data, 0600)
+}
+
+// removeState elimina el archivo de estado
+func removeState() error {
+	statePath, err := getStatePath()
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(statePath); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,127 @@
+package goenv
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestCovers(t *testing.T) {
+	for _, tc := range []struct {
+		pattern, target string
+		want            bool
+	}{
+		{"github.com/mi-empresa", "github.com/mi-empresa/core-lib", true},
+		{"github.com/mi-empresa", "github.com/mi-empresa/core-lib/v2", true},
+		{"github.com/mi-empresa", "github.com/mi-empresa", true},
+		{"github.com/mi-empresa", "github.com/mi-empresa-tools/lib", false},
+		{"github.com/mi-empresa/*", "github.com/mi-empresa/core-lib", true},
+		{"github.com/mi-empresa/*", "github.com/mi-empresa", false},
+		{"*.corp.com", "git.corp.com/team/lib", true},
+		{"github.com", "github.com/mi-empresa/*", true},
+		{"github.com/mi-empresa/core-lib", "github.com/mi-empresa", false},
+		{"github.com/mi-empresa/", "github.com/mi-empresa/lib/", true},
+	} {
+		if got := Covers(tc.pattern, tc.target); got != tc.want {
+			t.Errorf("Covers(%q, %q) = %v, se esperaba %v", tc.pattern, tc.target, got, tc.want)
+		}
+	}
+}
+
+func TestMergePatterns(t *testing.T) {
+	for _, tc := range []struct {
+		name                      string
+		existing, owned, patterns []string
+		merged, newOwned, added   []string
+	}{
+		{
+			name:     "agrega a los valores del usuario",
+			existing: []string{"corp.com"},
+			patterns: []string{"github.com/mi-empresa"},
+			merged:   []string{"corp.com", "github.com/mi-empresa"},
+			newOwned: []string{"github.com/mi-empresa"},
+			added:    []string{"github.com/mi-empresa"},
+		},
+		{
+			name:     "no agrega patrones ya cubiertos",
+			existing: []string{"github.com"},
+			patterns: []string{"github.com/mi-empresa", "github.com/otra/lib"},
+			merged:   []string{"github.com"},
+		},
+		{
+			name:     "no duplica ni repite patrones",
+			existing: []string{"github.com/a", "github.com/a"},
+			owned:    []string{"github.com/a"},
+			patterns: []string{"github.com/a", "github.com/b", "github.com/b"},
+			merged:   []string{"github.com/a", "github.com/b"},
+			newOwned: []string{"github.com/a", "github.com/b"},
+			added:    []string{"github.com/b"},
+		},
+		{
+			name:     "reemplaza patrones propios cubiertos por uno nuevo",
+			existing: []string{"github.com/mi-empresa/core-lib", "github.com/mi-empresa/*", "corp.com"},
+			owned:    []string{"github.com/mi-empresa/core-lib", "github.com/mi-empresa/*"},
+			patterns: []string{"github.com/mi-empresa"},
+			merged:   []string{"corp.com", "github.com/mi-empresa"},
+			newOwned: []string{"github.com/mi-empresa"},
+			added:    []string{"github.com/mi-empresa"},
+		},
+		{
+			name:     "nunca elimina patrones del usuario",
+			existing: []string{"github.com/mi-empresa/core-lib"},
+			patterns: []string{"github.com/mi-empresa"},
+			merged:   []string{"github.com/mi-empresa/core-lib", "github.com/mi-empresa"},
+			newOwned: []string{"github.com/mi-empresa"},
+			added:    []string{"github.com/mi-empresa"},
+		},
+	} {
+		merged, newOwned, added := MergePatterns(tc.existing, tc.owned, tc.patterns)
+		if !reflect.DeepEqual(merged, tc.merged) || !reflect.DeepEqual(newOwned, tc.newOwned) || !reflect.DeepEqual(added, tc.added) {
+			t.Errorf("%s:\n got: %v %v %v\nwant: %v %v %v", tc.name, merged, newOwned, added, tc.merged, tc.newOwned, tc.added)
+		}
+	}
+}
+
+func TestApplyDoesNotPersistProcessEnvironment(t *testing.T) {
+	home := t.TempDir()
+	goenvFile := filepath.Join(home, "go.env")
+	t.Setenv("HOME", home)
+	t.Setenv("GOENV", goenvFile)
+	if err := os.WriteFile(goenvFile, []byte("GOPRIVATE=corp.com\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	// Exportado en el shell: no debe terminar en el archivo de go env
+	t.Setenv(GOPRIVATE, "shell.example.com")
+
+	result, err := Apply([]string{"github.com/mi-empresa"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := result.Values[GOPRIVATE]; got != "corp.com,github.com/mi-empresa" {
+		t.Fatalf("GOPRIVATE = %q", got)
+	}
+	file, err := fileValues()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := file[GOPRIVATE]; got != "corp.com,github.com/mi-empresa" {
+		t.Fatalf("GOPRIVATE en %s = %q", goenvFile, got)
+	}
+
+	// Plan (exports para un proceso) sí parte del valor efectivo
+	planned, _, err := Plan([]string{"github.com/mi-empresa"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := planned.Values[GOPRIVATE]; got != "shell.example.com,github.com/mi-empresa" {
+		t.Fatalf("Plan GOPRIVATE = %q", got)
+	}
+
+	if _, err := Undo(); err != nil {
+		t.Fatal(err)
+	}
+	if file, _ = fileValues(); file[GOPRIVATE] != "corp.com" {
+		t.Fatalf("GOPRIVATE después de Undo = %q", file[GOPRIVATE])
+	}
+}
@@ -0,0 +1,104 @@
+package gomod
+
+import (
+	"os"
+
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/module"
+)
+
+// Require representa una dependencia declarada en go.mod
+type Require struct {
+	Path     string
+	Version  string
+	Indirect bool
+}
+
+// Retract representa una directiva retract: una versión o un rango [Low, High]
+type Retract struct {
+	Low       string
+	High      string
+	Rationale string
+}
+
+// File representa el contenido relevante de un archivo go.mod
+type File struct {
+	Module  string
+	Go      string
+	Require []Require
+	Retract []Retract
+}
+
+// Parse lee un archivo go.mod y extrae módulo, versión de Go y dependencias
+func Parse(path string) (*File, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return parse(path, data)
+}
+
+// ParseData parsea el contenido de un go.mod ya leído
+func ParseData(data []byte) (*File, error) {
+	return parse("go.mod", data)
+}
+
+// parse usa modfile.ParseLax, igual que el comando go con el go.mod de una
+// dependencia: ignora replace, exclude y directivas desconocidas
+func parse(name string, data []byte) (*File, error) {
+	mf, err := modfile.ParseLax(name, data, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	f := &File{}
+	if mf.Module != nil {
+		f.Module = mf.Module.Mod.Path
+	}
+	if mf.Go != nil {
+		f.Go = mf.Go.Version
+	}
+	for _, r := range mf.Require {
+		f.Require = append(f.Require, Require{Path: r.Mod.Path, Version: r.Mod.Version, Indirect: r.Indirect})
+	}
+	for _, r := range mf.Retract {
+		f.Retract = append(f.Retract, Retract{Low: r.Low, High: r.High, Rationale: r.Rationale})
+	}
+
+	return f, nil
+}
+
+// Paths retorna los paths de todas las dependencias
+func (f *File) Paths() []string {
+	var paths []string
+	for _, r := range f.Require {
+		paths = append(paths, r.Path)
+	}
+	return paths
+}
+
+// FindRequire busca una dependencia por path
+func (f *File) FindRequire(path string) (Require, bool) {
+	for _, r := range f.Require {
+		if r.Path == path {
+			return r, true
+		}
+	}
+	return Require{}, false
+}
+
+// Retracted indica si una versión fue retractada y retorna el motivo declarado
+func (f *File) Retracted(version string) (bool, string) {
+	for _, r := range f.Retract {
+		if CompareVersions(version, r.Low) >= 0 && CompareVersions(version, r.High) <= 0 {
+			return true, r.Rationale
+		}
+	}
+	return false, ""
+}
+
+// IsPseudoVersion verifica si una versión es una pseudo-versión
+func IsPseudoVersion(version string) bool {
+	return module.IsPseudoVersion(version)
+}
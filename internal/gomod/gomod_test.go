@@ -0,0 +1,123 @@
+package gomod
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+const testGoMod = `module "github.com/org/service" // comentario
+
+go 1.22
+
+require github.com/org/lib v1.2.0
+
+require (
+	github.com/org/util v0.3.0 // indirect
+	golang.org/x/mod v0.21.0
+)
+
+replace github.com/org/lib => ../lib
+
+exclude github.com/org/util v0.2.0
+
+retract [v1.0.0, v1.0.5] // publicado con un bug de seguridad
+
+// versión vacía por error
+retract v1.1.0
+
+tool golang.org/x/tools/cmd/stringer
+`
+
+func TestParseData(t *testing.T) {
+	f, err := ParseData([]byte(testGoMod))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if f.Module != "github.com/org/service" || f.Go != "1.22" {
+		t.Fatalf("module/go: %q %q", f.Module, f.Go)
+	}
+
+	wantRequire := []Require{
+		{Path: "github.com/org/lib", Version: "v1.2.0"},
+		{Path: "github.com/org/util", Version: "v0.3.0", Indirect: true},
+		{Path: "golang.org/x/mod", Version: "v0.21.0"},
+	}
+	if !reflect.DeepEqual(f.Require, wantRequire) {
+		t.Fatalf("require: %+v", f.Require)
+	}
+
+	for _, tc := range []struct {
+		version   string
+		retracted bool
+		rationale string
+	}{
+		{"v1.0.3", true, "publicado con un bug de seguridad"},
+		{"v1.1.0", true, "versión vacía por error"},
+		{"v1.0.6", false, ""},
+	} {
+		retracted, rationale := f.Retracted(tc.version)
+		if retracted != tc.retracted || rationale != tc.rationale {
+			t.Errorf("Retracted(%s) = %v, %q", tc.version, retracted, rationale)
+		}
+	}
+}
+
+func TestParseDataRejectsInvalidGoMod(t *testing.T) {
+	if _, err := ParseData([]byte("module github.com/org/lib\n\nrequire (\n\tgithub.com/org/util\n)\n")); err == nil {
+		t.Fatal("se esperaba un error por un require sin versión")
+	}
+}
+
+func TestIsPseudoVersion(t *testing.T) {
+	for version, want := range map[string]bool{
+		"v0.0.0-20240101120000-abcdefabcdef":        true,
+		"v1.2.4-0.20240101120000-abcdefabcdef":      true,
+		"v1.2.3-rc.1.0.20240101120000-abcdefabcdef": true,
+		"v1.2.3":      false,
+		"v1.2.3-rc.1": false,
+	} {
+		if got := IsPseudoVersion(version); got != want {
+			t.Errorf("IsPseudoVersion(%q) = %v", version, got)
+		}
+	}
+}
+
+func TestLoadWorkspace(t *testing.T) {
+	root := t.TempDir()
+	files := map[string]string{
+		"go.work":          "go 1.22\n\nuse (\n\t./api // servicio\n\t\"./libs/core\"\n)\n\nuse ./tools\n\nreplace github.com/org/x => ../x\n",
+		"api/go.mod":       "module github.com/org/api\n\ngo 1.22\n\nrequire github.com/org/core v0.0.0-20240101120000-abcdefabcdef\n",
+		"libs/core/go.mod": "module github.com/org/core\n\ngo 1.22\n",
+		"tools/go.mod":     "module github.com/org/tools\n\ngo 1.22\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ws, err := LoadWorkspace(filepath.Join(root, "go.work"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]bool{"github.com/org/api": true, "github.com/org/core": true, "github.com/org/tools": true}
+	if got := ws.ModulePaths(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("ModulePaths = %v", got)
+	}
+
+	api := ws.ModuleForDir(filepath.Join(root, "api"))
+	if api == nil || api.File.Module != "github.com/org/api" {
+		t.Fatalf("ModuleForDir: %+v", api)
+	}
+	if unreleased := ws.UnreleasedSiblings(api); len(unreleased) != 1 || unreleased[0].Path != "github.com/org/core" {
+		t.Fatalf("UnreleasedSiblings: %+v", unreleased)
+	}
+}
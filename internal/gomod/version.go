@@ -0,0 +1,115 @@
+package gomod
+
+import (
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+
+	"golang.org/x/mod/module"
+	"golang.org/x/mod/semver"
+)
+
+// IsValidVersion verifica si una versión es semver canónica para un módulo Go
+// (vX.Y.Z con prerelease y +incompatible opcionales; no admite v1 ni v1.2)
+func IsValidVersion(version string) bool {
+	canonical := semver.Canonical(version)
+	return canonical != "" && (version == canonical || version == canonical+"+incompatible")
+}
+
+// Major retorna la versión mayor (v1.2.3 -> 1); -1 si la versión no es válida
+func Major(version string) int {
+	if !IsValidVersion(version) {
+		return -1
+	}
+	major, _ := strconv.Atoi(strings.TrimPrefix(semver.Major(version), "v"))
+	return major
+}
+
+// SplitMajorSuffix separa el sufijo de versión mayor de un module path
+// Ejemplo: "github.com/org/lib/v2" -> "github.com/org/lib", 2
+// Sin sufijo retorna el path completo y 0
+func SplitMajorSuffix(modulePath string) (string, int) {
+	prefix, pathMajor, ok := module.SplitPathVersion(modulePath)
+	if !ok || !strings.HasPrefix(pathMajor, "/v") {
+		return modulePath, 0
+	}
+	major, _ := strconv.Atoi(strings.TrimPrefix(pathMajor, "/v"))
+	return prefix, major
+}
+
+// TagPrefix retorna el prefijo de los tags de un módulo a partir de su
+// directorio relativo a la raíz del repositorio ("." o vacío para la raíz).
+// Un módulo /vN en el subdirectorio vN usa los tags del directorio padre.
+// Ejemplo: "github.com/org/repo/lib/v2", "lib/v2" -> "lib/"
+func TagPrefix(modulePath, dir string) string {
+	dir = strings.Trim(path.Clean(dir), "/")
+	if _, major := SplitMajorSuffix(modulePath); major >= 2 && path.Base(dir) == fmt.Sprintf("v%d", major) {
+		dir = path.Dir(dir)
+	}
+	if dir == "." || dir == "" {
+		return ""
+	}
+	return dir + "/"
+}
+
+// CheckMajor verifica que la versión mayor corresponda al sufijo /vN del
+// módulo: v0 o v1 sin sufijo, vN con /vN
+func CheckMajor(modulePath, version string) error {
+	major := Major(version)
+	if major < 0 {
+		return fmt.Errorf("versión inválida: %s", version)
+	}
+
+	_, suffix := SplitMajorSuffix(modulePath)
+	switch {
+	case suffix == 0 && major >= 2:
+		return fmt.Errorf("la versión %s requiere que el módulo %s termine en /v%d", version, modulePath, major)
+	case suffix >= 2 && major != suffix:
+		return fmt.Errorf("el módulo %s solo admite versiones v%d.x.x (se indicó %s)", modulePath, suffix, version)
+	}
+	return nil
+}
+
+// CompareVersions compara dos versiones según la precedencia de semver
+// Retorna -1 si a < b, 0 si son iguales y 1 si a > b. Las versiones
+// inválidas se consideran menores que cualquier versión válida.
+func CompareVersions(a, b string) int {
+	validA, validB := IsValidVersion(a), IsValidVersion(b)
+	switch {
+	case !validA && !validB:
+		return strings.Compare(a, b)
+	case !validA:
+		return -1
+	case !validB:
+		return 1
+	}
+	return semver.Compare(a, b)
+}
+
+// LatestVersion retorna la versión más alta, prefiriendo releases sobre
+// prereleases (el mismo criterio que @latest del comando go)
+func LatestVersion(versions []string) string {
+	latest, latestPre := "", ""
+	for _, v := range versions {
+		if IsPrerelease(v) {
+			if latestPre == "" || CompareVersions(v, latestPre) > 0 {
+				latestPre = v
+			}
+			continue
+		}
+		if latest == "" || CompareVersions(v, latest) > 0 {
+			latest = v
+		}
+	}
+
+	if latest != "" {
+		return latest
+	}
+	return latestPre
+}
+
+// IsPrerelease indica si una versión tiene prerelease (ej: v1.2.0-rc.1)
+func IsPrerelease(version string) bool {
+	return semver.Prerelease(version) != ""
+}
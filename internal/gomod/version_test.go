@@ -0,0 +1,115 @@
+package gomod
+
+import "testing"
+
+func TestTagPrefix(t *testing.T) {
+	for _, tc := range []struct {
+		module, dir, want string
+	}{
+		{"github.com/org/repo", ".", ""},
+		{"github.com/org/repo", "", ""},
+		{"github.com/org/repo/lib", "lib", "lib/"},
+		{"github.com/org/repo/v2", "v2", ""},
+		{"github.com/org/repo/lib/v2", "lib/v2", "lib/"},
+		{"github.com/org/repo/lib/v2", "lib", "lib/"},
+		{"github.com/org/repo/lib/v3", "lib/v2", "lib/v2/"},
+		{"github.com/org/repo/tools/v2", "tools/v2/", "tools/"},
+	} {
+		if got := TagPrefix(tc.module, tc.dir); got != tc.want {
+			t.Errorf("TagPrefix(%q, %q) = %q, se esperaba %q", tc.module, tc.dir, got, tc.want)
+		}
+	}
+}
+
+func TestCheckMajor(t *testing.T) {
+	for _, tc := range []struct {
+		module, version string
+		ok              bool
+	}{
+		{"github.com/org/lib", "v0.3.0", true},
+		{"github.com/org/lib", "v1.2.3", true},
+		{"github.com/org/lib", "v2.0.0", false},
+		{"github.com/org/lib/v2", "v2.1.0", true},
+		{"github.com/org/lib/v2", "v2.1.0-rc.1", true},
+		{"github.com/org/lib/v2", "v1.9.0", false},
+		{"github.com/org/lib/v2", "v3.0.0", false},
+		{"github.com/org/lib/v2", "2.0.0", false},
+	} {
+		err := CheckMajor(tc.module, tc.version)
+		if (err == nil) != tc.ok {
+			t.Errorf("CheckMajor(%q, %q) = %v", tc.module, tc.version, err)
+		}
+	}
+}
+
+func TestIsValidVersion(t *testing.T) {
+	for _, tc := range []struct {
+		version string
+		want    bool
+	}{
+		{"v1.2.3", true},
+		{"v0.0.0-20240101120000-abcdefabcdef", true},
+		{"v2.0.0-rc.1", true},
+		{"v2.0.0+incompatible", true},
+		{"v1.2", false},
+		{"v1", false},
+		{"1.2.3", false},
+		{"v1.2.3+build", false},
+		{"v01.2.3", false},
+		{"sub/v1.2.3", false},
+	} {
+		if got := IsValidVersion(tc.version); got != tc.want {
+			t.Errorf("IsValidVersion(%q) = %v, se esperaba %v", tc.version, got, tc.want)
+		}
+	}
+}
+
+func TestCompareVersions(t *testing.T) {
+	for _, tc := range []struct {
+		a, b string
+		want int
+	}{
+		{"v1.2.3", "v1.2.3", 0},
+		{"v1.10.0", "v1.9.0", 1},
+		{"v1.0.0-rc.1", "v1.0.0", -1},
+		{"v1.0.0-rc.2", "v1.0.0-rc.10", -1},
+		{"v1.0.0-alpha", "v1.0.0-alpha.1", -1},
+		{"v1.0.0-beta", "v1.0.0-alpha.1", 1},
+		{"v2.0.0+incompatible", "v2.0.0", 0},
+		{"latest", "v0.0.1", -1},
+		{"v0.0.1", "main", 1},
+		{"dev", "main", -1},
+	} {
+		if got := CompareVersions(tc.a, tc.b); got != tc.want {
+			t.Errorf("CompareVersions(%q, %q) = %d, se esperaba %d", tc.a, tc.b, got, tc.want)
+		}
+	}
+}
+
+func TestLatestVersionPrefersReleases(t *testing.T) {
+	if got := LatestVersion([]string{"v1.0.0", "v1.2.0-rc.1", "v1.1.0"}); got != "v1.1.0" {
+		t.Errorf("LatestVersion = %s, se esperaba v1.1.0", got)
+	}
+	if got := LatestVersion([]string{"v1.2.0-rc.1", "v1.2.0-beta.1"}); got != "v1.2.0-rc.1" {
+		t.Errorf("LatestVersion sin releases = %s, se esperaba v1.2.0-rc.1", got)
+	}
+}
+
+func TestSplitMajorSuffix(t *testing.T) {
+	for _, tc := range []struct {
+		module, base string
+		major        int
+	}{
+		{"github.com/org/lib", "github.com/org/lib", 0},
+		{"github.com/org/lib/v2", "github.com/org/lib", 2},
+		{"github.com/org/lib/v10", "github.com/org/lib", 10},
+		{"github.com/org/lib/v1", "github.com/org/lib/v1", 0},
+		{"github.com/org/lib/v02", "github.com/org/lib/v02", 0},
+		{"gopkg.in/yaml.v3", "gopkg.in/yaml.v3", 0},
+	} {
+		base, major := SplitMajorSuffix(tc.module)
+		if base != tc.base || major != tc.major {
+			t.Errorf("SplitMajorSuffix(%q) = %q, %d; se esperaba %q, %d", tc.module, base, major, tc.base, tc.major)
+		}
+	}
+}
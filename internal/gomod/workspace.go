@@ -0,0 +1,163 @@
+package gomod
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/mod/modfile"
+)
+
+// WorkspaceModule representa un módulo incluido con 'use' en go.work
+type WorkspaceModule struct {
+	Dir  string
+	File *File
+}
+
+// Workspace representa un archivo go.work y sus módulos
+type Workspace struct {
+	Path    string
+	Dir     string
+	Modules []WorkspaceModule
+}
+
+// FindWorkspace busca go.work subiendo desde dir hasta la raíz.
+// Respeta GOWORK: "off" desactiva el workspace y una ruta explícita se usa tal cual.
+// Retorna "" si no hay workspace.
+func FindWorkspace(dir string) (string, error) {
+	switch gowork := os.Getenv("GOWORK"); gowork {
+	case "off":
+		return "", nil
+	case "":
+	default:
+		return filepath.Abs(gowork)
+	}
+
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+
+	for {
+		candidate := filepath.Join(dir, "go.work")
+		if info, err := os.Stat(candidate); err == nil && !info.IsDir() {
+			return candidate, nil
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", nil
+		}
+		dir = parent
+	}
+}
+
+// LoadWorkspace lee un go.work y el go.mod de cada módulo usado
+func LoadWorkspace(path string) (*Workspace, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	wf, err := modfile.ParseWork(path, data, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	ws := &Workspace{
+		Path: path,
+		Dir:  filepath.Dir(path),
+	}
+
+	var useDirs []string
+	for _, u := range wf.Use {
+		useDirs = append(useDirs, u.Path)
+	}
+
+	for _, d := range useDirs {
+		dir := d
+		if !filepath.IsAbs(dir) {
+			dir = filepath.Join(ws.Dir, dir)
+		}
+		dir = filepath.Clean(dir)
+
+		f, err := Parse(filepath.Join(dir, "go.mod"))
+		if err != nil {
+			return nil, fmt.Errorf("error al leer módulo '%s' del workspace: %w", d, err)
+		}
+
+		ws.Modules = append(ws.Modules, WorkspaceModule{Dir: dir, File: f})
+	}
+
+	return ws, nil
+}
+
+// ModulePaths retorna los paths de los módulos del workspace
+func (w *Workspace) ModulePaths() map[string]bool {
+	paths := make(map[string]bool)
+	for _, m := range w.Modules {
+		paths[m.File.Module] = true
+	}
+	return paths
+}
+
+// Dependencies retorna las dependencias de todos los módulos del workspace,
+// sin duplicados y excluyendo los propios módulos del workspace
+func (w *Workspace) Dependencies() []string {
+	local := w.ModulePaths()
+	seen := make(map[string]bool)
+
+	var deps []string
+	for _, m := range w.Modules {
+		for _, r := range m.File.Require {
+			if local[r.Path] || seen[r.Path] {
+				continue
+			}
+			seen[r.Path] = true
+			deps = append(deps, r.Path)
+		}
+	}
+
+	return deps
+}
+
+// ModuleForDir retorna el módulo del workspace que contiene dir (el más profundo)
+func (w *Workspace) ModuleForDir(dir string) *WorkspaceModule {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil
+	}
+
+	var best *WorkspaceModule
+	for i := range w.Modules {
+		m := &w.Modules[i]
+		rel, err := filepath.Rel(m.Dir, dir)
+		if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			continue
+		}
+		if best == nil || len(m.Dir) > len(best.Dir) {
+			best = m
+		}
+	}
+
+	return best
+}
+
+// UnreleasedSiblings retorna los requires de m hacia otros módulos del workspace
+// que están fijados a una pseudo-versión (es decir, sin release propio)
+func (w *Workspace) UnreleasedSiblings(m *WorkspaceModule) []Require {
+	local := w.ModulePaths()
+
+	var unreleased []Require
+	for _, r := range m.File.Require {
+		if r.Path == m.File.Module || !local[r.Path] {
+			continue
+		}
+		if IsPseudoVersion(r.Version) {
+			unreleased = append(unreleased, r)
+		}
+	}
+
+	return unreleased
+}
@@ -0,0 +1,156 @@
+package modproxy
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/reitmas32/next/internal/gomod"
+	"golang.org/x/mod/module"
+)
+
+// Layout de archivos de GOPROXY (compatible con GOPROXY=file:///...):
+//   <root>/<module escapado>/@v/list
+//   <root>/<module escapado>/@v/<versión escapada>.info|.mod|.zip
+
+// VersionDir retorna el directorio @v de un módulo. El path se escapa con
+// module.EscapePath (cada mayúscula se reemplaza por '!' y la minúscula).
+func VersionDir(root, modulePath string) (string, error) {
+	escaped, err := module.EscapePath(modulePath)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(root, filepath.FromSlash(escaped), "@v"), nil
+}
+
+// VersionFile retorna la ruta de un archivo de versión (ext: .info, .mod, .zip)
+func VersionFile(root, modulePath, version, ext string) (string, error) {
+	dir, err := VersionDir(root, modulePath)
+	if err != nil {
+		return "", err
+	}
+	escaped, err := module.EscapeVersion(version)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, escaped+ext), nil
+}
+
+// HasVersion indica si la versión ya está completa en el layout
+func HasVersion(root, modulePath, version string) bool {
+	for _, ext := range []string{".info", ".mod", ".zip"} {
+		file, err := VersionFile(root, modulePath, version, ext)
+		if err != nil {
+			return false
+		}
+		if _, err := os.Stat(file); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// WriteVersion genera y escribe .info, .mod y .zip de una versión
+func WriteVersion(root string, src *Source, version string) error {
+	b, err := src.Build(version)
+	if err != nil {
+		return err
+	}
+	return WriteBuild(root, b)
+}
+
+// WriteBuild escribe los archivos de una versión. El .zip se escribe al
+// final (con un archivo temporal) porque HasVersion lo usa como marca de
+// versión completa.
+func WriteBuild(root string, b *Build) error {
+	paths := make(map[string]string)
+	for _, ext := range []string{".info", ".mod", ".zip"} {
+		file, err := VersionFile(root, b.Module, b.Version, ext)
+		if err != nil {
+			return err
+		}
+		paths[ext] = file
+	}
+
+	dir := filepath.Dir(paths[".zip"])
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("error al crear %s: %w", dir, err)
+	}
+
+	if err := os.WriteFile(paths[".info"], b.Info, 0644); err != nil {
+		return err
+	}
+	if err := os.WriteFile(paths[".mod"], b.Mod, 0644); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(dir, ".tmp-*.zip")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(b.Zip); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), paths[".zip"])
+}
+
+// ReadList lee las versiones del archivo list de un módulo
+func ReadList(root, modulePath string) ([]string, error) {
+	dir, err := VersionDir(root, modulePath)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "list"))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return strings.Fields(string(data)), nil
+}
+
+// AddToList agrega versiones al archivo list del módulo (ordenado, sin duplicados)
+func AddToList(root, modulePath string, versions []string) error {
+	existing, err := ReadList(root, modulePath)
+	if err != nil {
+		return err
+	}
+
+	seen := make(map[string]bool)
+	var all []string
+	for _, v := range append(existing, versions...) {
+		if !seen[v] {
+			seen[v] = true
+			all = append(all, v)
+		}
+	}
+
+	sort.Slice(all, func(i, j int) bool {
+		return gomod.CompareVersions(all[i], all[j]) < 0
+	})
+
+	dir, err := VersionDir(root, modulePath)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	content := strings.Join(all, "\n")
+	if content != "" {
+		content += "\n"
+	}
+	return os.WriteFile(filepath.Join(dir, "list"), []byte(content), 0644)
+}
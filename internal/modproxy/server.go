@@ -0,0 +1,308 @@
+package modproxy
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/reitmas32/next/internal/gomod"
+	"golang.org/x/mod/module"
+)
+
+// ResolveFunc retorna la fuente de un módulo privado o nil si el módulo no
+// corresponde a ninguna cuenta (y debe reenviarse al upstream)
+type ResolveFunc func(module string) (*Source, error)
+
+// Server implementa el protocolo HTTP de GOPROXY para módulos privados
+type Server struct {
+	// CacheDir guarda los .info/.mod/.zip generados con el layout de GOPROXY
+	CacheDir string
+	// Upstream es el proxy al que se reenvían los módulos públicos (vacío: 404)
+	Upstream string
+	// SyncInterval es el tiempo mínimo entre fetch de un mismo repositorio
+	SyncInterval time.Duration
+	// Resolve ubica la fuente de un módulo privado
+	Resolve ResolveFunc
+	// Allow son las redes (además de loopback) que pueden usar el servidor.
+	// El proxy sirve código privado sin autenticación, así que cualquier otro
+	// cliente recibe 403.
+	Allow []*net.IPNet
+	// Logf registra cada petición (opcional)
+	Logf func(format string, args ...interface{})
+
+	mu      sync.Mutex
+	sources map[string]*Source
+	locks   map[string]*sync.Mutex
+	synced  map[string]time.Time
+}
+
+// upstreamClient consulta el upstream; un upstream colgado no debe bloquear
+// al comando go para siempre
+var upstreamClient = &http.Client{Timeout: 2 * time.Minute}
+
+// errNotFound se responde con 404 para que el comando go pruebe el siguiente proxy
+var errNotFound = errors.New("not found")
+
+// ServeHTTP atiende /<module>/@v/list, /<module>/@v/<version>.{info,mod,zip} y /<module>/@latest
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	status := s.serve(w, r)
+	if s.Logf != nil {
+		s.Logf("%s %s %d %s", r.Method, r.URL.Path, status, time.Since(start).Round(time.Millisecond))
+	}
+}
+
+func (s *Server) serve(w http.ResponseWriter, r *http.Request) int {
+	if !s.allowed(r.RemoteAddr) {
+		http.Error(w, "forbidden", http.StatusForbidden)
+		return http.StatusForbidden
+	}
+
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return http.StatusMethodNotAllowed
+	}
+
+	escapedModule, file, ok := splitRequestPath(r.URL.Path)
+	if !ok {
+		http.NotFound(w, r)
+		return http.StatusNotFound
+	}
+
+	modulePath, err := module.UnescapePath(escapedModule)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return http.StatusBadRequest
+	}
+
+	src, err := s.source(modulePath)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return http.StatusBadGateway
+	}
+	if src == nil {
+		return s.forward(w, r)
+	}
+
+	data, contentType, err := s.private(src, file)
+	switch {
+	case errors.Is(err, errNotFound):
+		http.Error(w, "not found", http.StatusNotFound)
+		return http.StatusNotFound
+	case err != nil:
+		// 404 también para errores de versión: el comando go muestra el mensaje
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return http.StatusNotFound
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(http.StatusOK)
+	if r.Method == http.MethodGet {
+		_, _ = w.Write(data)
+	}
+	return http.StatusOK
+}
+
+// allowed indica si el cliente es loopback o está en alguna red de Allow
+func (s *Server) allowed(remoteAddr string) bool {
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		host = remoteAddr
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return false
+	}
+	if ip.IsLoopback() {
+		return true
+	}
+	for _, network := range s.Allow {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// splitRequestPath separa "/github.com/org/lib/@v/v1.0.0.zip" en módulo y archivo
+func splitRequestPath(urlPath string) (module, file string, ok bool) {
+	urlPath = strings.TrimPrefix(urlPath, "/")
+	if strings.HasSuffix(urlPath, "/@latest") {
+		return strings.TrimSuffix(urlPath, "/@latest"), "@latest", true
+	}
+
+	i := strings.LastIndex(urlPath, "/@v/")
+	if i <= 0 {
+		return "", "", false
+	}
+	return urlPath[:i], urlPath[i+len("/@v/"):], true
+}
+
+// private genera la respuesta para un módulo privado
+func (s *Server) private(src *Source, file string) ([]byte, string, error) {
+	lock := s.lock(src.GitDir)
+	lock.Lock()
+	defer lock.Unlock()
+
+	if file == "list" || file == "@latest" {
+		if err := s.sync(src); err != nil {
+			return nil, "", err
+		}
+		versions, err := src.Versions()
+		if err != nil {
+			return nil, "", err
+		}
+
+		if file == "list" {
+			return []byte(strings.Join(versions, "\n") + "\n"), "text/plain; charset=utf-8", nil
+		}
+
+		latest := gomod.LatestVersion(versions)
+		if latest == "" {
+			return nil, "", errNotFound
+		}
+		return s.cached(src, latest, ".info")
+	}
+
+	ext := filepath.Ext(file)
+	if ext != ".info" && ext != ".mod" && ext != ".zip" {
+		return nil, "", errNotFound
+	}
+
+	version, err := module.UnescapeVersion(strings.TrimSuffix(file, ext))
+	if err != nil {
+		return nil, "", err
+	}
+	return s.cached(src, version, ext)
+}
+
+// cached sirve un archivo de versión desde el cache, generándolo si no existe
+func (s *Server) cached(src *Source, version, ext string) ([]byte, string, error) {
+	if !HasVersion(s.CacheDir, src.Module, version) {
+		if _, err := src.resolve(version); err != nil {
+			// Puede ser un tag nuevo que aún no se trajo
+			if err := s.sync(src); err != nil {
+				return nil, "", err
+			}
+		}
+		if err := WriteVersion(s.CacheDir, src, version); err != nil {
+			return nil, "", err
+		}
+	}
+
+	path, err := VersionFile(s.CacheDir, src.Module, version, ext)
+	if err != nil {
+		return nil, "", err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, "", err
+	}
+
+	contentType := "text/plain; charset=utf-8"
+	switch ext {
+	case ".info":
+		contentType = "application/json"
+	case ".zip":
+		contentType = "application/zip"
+	}
+	return data, contentType, nil
+}
+
+// sync trae los tags del repositorio como máximo una vez por SyncInterval
+func (s *Server) sync(src *Source) error {
+	s.mu.Lock()
+	last, ok := s.synced[src.GitDir]
+	s.mu.Unlock()
+
+	if ok && time.Since(last) < s.SyncInterval {
+		return nil
+	}
+
+	if err := src.Sync(); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.synced[src.GitDir] = time.Now()
+	s.mu.Unlock()
+	return nil
+}
+
+// source resuelve (una sola vez) la fuente de un módulo
+func (s *Server) source(module string) (*Source, error) {
+	s.mu.Lock()
+	if s.sources == nil {
+		s.sources = make(map[string]*Source)
+		s.locks = make(map[string]*sync.Mutex)
+		s.synced = make(map[string]time.Time)
+	}
+	src, ok := s.sources[module]
+	s.mu.Unlock()
+
+	if ok {
+		return src, nil
+	}
+
+	src, err := s.Resolve(module)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	s.sources[module] = src
+	s.mu.Unlock()
+	return src, nil
+}
+
+// lock retorna el mutex de un repositorio (git no admite fetch concurrentes)
+func (s *Server) lock(gitDir string) *sync.Mutex {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	l, ok := s.locks[gitDir]
+	if !ok {
+		l = &sync.Mutex{}
+		s.locks[gitDir] = l
+	}
+	return l
+}
+
+// forward reenvía la petición de un módulo público al upstream
+func (s *Server) forward(w http.ResponseWriter, r *http.Request) int {
+	if s.Upstream == "" {
+		http.Error(w, "not found", http.StatusNotFound)
+		return http.StatusNotFound
+	}
+
+	req, err := http.NewRequestWithContext(r.Context(), http.MethodGet, strings.TrimSuffix(s.Upstream, "/")+r.URL.EscapedPath(), nil)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return http.StatusBadGateway
+	}
+
+	resp, err := upstreamClient.Do(req)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("error al consultar upstream: %v", err), http.StatusBadGateway)
+		return http.StatusBadGateway
+	}
+	defer resp.Body.Close()
+
+	for _, h := range []string{"Content-Type", "Content-Length", "Cache-Control"} {
+		if v := resp.Header.Get(h); v != "" {
+			w.Header().Set(h, v)
+		}
+	}
+	w.WriteHeader(resp.StatusCode)
+	if r.Method == http.MethodGet {
+		_, _ = io.Copy(w, resp.Body)
+	}
+	return resp.StatusCode
+}
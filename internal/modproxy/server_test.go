@@ -0,0 +1,42 @@
+package modproxy
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestServerRejectsClientsOutsideAllow(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("v1.0.0\n"))
+	}))
+	defer upstream.Close()
+
+	_, office, _ := net.ParseCIDR("10.1.0.0/16")
+	s := &Server{
+		Upstream: upstream.URL,
+		Allow:    []*net.IPNet{office},
+		Resolve:  func(string) (*Source, error) { return nil, nil },
+	}
+
+	for _, tc := range []struct {
+		remote string
+		want   int
+	}{
+		{"127.0.0.1:5000", http.StatusOK},
+		{"[::1]:5000", http.StatusOK},
+		{"10.1.2.3:5000", http.StatusOK},
+		{"10.2.0.1:5000", http.StatusForbidden},
+		{"203.0.113.7:5000", http.StatusForbidden},
+	} {
+		req := httptest.NewRequest(http.MethodGet, "/example.com/lib/@v/list", nil)
+		req.RemoteAddr = tc.remote
+		rec := httptest.NewRecorder()
+		s.ServeHTTP(rec, req)
+
+		if rec.Code != tc.want {
+			t.Errorf("%s: status %d, se esperaba %d", tc.remote, rec.Code, tc.want)
+		}
+	}
+}
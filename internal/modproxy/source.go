@@ -0,0 +1,298 @@
+package modproxy
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/reitmas32/next/internal/git"
+	"github.com/reitmas32/next/internal/gomod"
+)
+
+// Source genera los archivos de GOPROXY de un módulo a partir de su
+// repositorio git (clonado como mirror bare en GitDir)
+type Source struct {
+	// Module es el module path (ej: github.com/org/lib/v2)
+	Module string
+	// RepoURL es la URL del repositorio real
+	RepoURL string
+	// Subdir es el directorio del módulo dentro del repositorio (vacío en la raíz)
+	Subdir string
+	// GitDir es el clon bare usado como cache
+	GitDir string
+	// Env se agrega al entorno de git al sincronizar (ej: credenciales)
+	Env []string
+}
+
+// Info es el contenido del archivo .info de una versión
+type Info struct {
+	Version string
+	Time    time.Time
+}
+
+// revision es una versión resuelta en el repositorio
+type revision struct {
+	Tag   string
+	Dir   string
+	GoMod []byte
+}
+
+// Sync clona o actualiza el repositorio
+func (s *Source) Sync() error {
+	return git.SyncBareRepo(s.GitDir, s.RepoURL, s.Env)
+}
+
+// tagPrefix retorna el prefijo de los tags del módulo (ej: "sub/" para sub/v1.0.0)
+func (s *Source) tagPrefix() string {
+	if s.Subdir == "" {
+		return ""
+	}
+	return s.Subdir + "/"
+}
+
+// Versions lista las versiones válidas del módulo a partir de los tags,
+// ordenadas. Los tags se filtran por prefijo y mayor antes de tocar el
+// repositorio y los objetos de todas las versiones se leen con un solo
+// 'git cat-file --batch'.
+func (s *Source) Versions() ([]string, error) {
+	tags, err := git.BareTags(s.GitDir)
+	if err != nil {
+		return nil, err
+	}
+
+	_, moduleMajor := gomod.SplitMajorSuffix(s.Module)
+	prefix := s.tagPrefix()
+
+	var candidates, objects []string
+	for _, tag := range tags {
+		if !strings.HasPrefix(tag, prefix) {
+			continue
+		}
+		version := strings.TrimPrefix(tag, prefix)
+		if !gomod.IsValidVersion(version) || strings.Contains(version, "+") {
+			continue
+		}
+
+		// v2+ sin sufijo /vN en el module path solo es válido como +incompatible
+		if moduleMajor == 0 && gomod.Major(version) >= 2 {
+			version += "+incompatible"
+		}
+		if s.checkMajor(version) != nil {
+			continue
+		}
+
+		candidates = append(candidates, version)
+		objects = append(objects, s.objects(version)...)
+	}
+
+	found, err := git.BareReadObjects(s.GitDir, objects)
+	if err != nil {
+		return nil, err
+	}
+
+	var versions []string
+	for _, version := range candidates {
+		if _, err := s.revision(version, found); err == nil {
+			versions = append(versions, version)
+		}
+	}
+
+	sort.Slice(versions, func(i, j int) bool {
+		return gomod.CompareVersions(versions[i], versions[j]) < 0
+	})
+	return versions, nil
+}
+
+// resolve valida una versión y ubica su tag, directorio y go.mod
+func (s *Source) resolve(version string) (*revision, error) {
+	if err := s.checkMajor(version); err != nil {
+		return nil, err
+	}
+
+	found, err := git.BareReadObjects(s.GitDir, s.objects(version))
+	if err != nil {
+		return nil, err
+	}
+	return s.revision(version, found)
+}
+
+// checkMajor valida que la versión corresponda a la mayor del module path
+func (s *Source) checkMajor(version string) error {
+	if !gomod.IsValidVersion(version) {
+		return fmt.Errorf("versión inválida: %s", version)
+	}
+
+	incompatible := strings.HasSuffix(version, "+incompatible")
+	_, moduleMajor := gomod.SplitMajorSuffix(s.Module)
+	major := gomod.Major(strings.TrimSuffix(version, "+incompatible"))
+
+	switch {
+	case moduleMajor >= 2 && (major != moduleMajor || incompatible):
+		return fmt.Errorf("%s no corresponde a %s", version, s.Module)
+	case moduleMajor == 0 && major >= 2 && !incompatible:
+		return fmt.Errorf("%s requiere el sufijo /v%d en el module path", version, major)
+	case incompatible && major < 2:
+		return fmt.Errorf("versión inválida: %s", version)
+	}
+	return nil
+}
+
+// tagRef retorna la referencia del tag de una versión
+func (s *Source) tagRef(version string) string {
+	return "refs/tags/" + s.tagPrefix() + strings.TrimSuffix(version, "+incompatible")
+}
+
+// majorDir retorna el subdirectorio vN de un módulo /vN (vacío si no aplica)
+func (s *Source) majorDir() string {
+	_, moduleMajor := gomod.SplitMajorSuffix(s.Module)
+	if moduleMajor < 2 {
+		return ""
+	}
+	return path.Join(s.Subdir, fmt.Sprintf("v%d", moduleMajor))
+}
+
+// objects retorna los objetos de git que revision necesita para una versión
+func (s *Source) objects(version string) []string {
+	tag := s.tagRef(version)
+	objects := []string{tag + "^{commit}", tag + ":" + path.Join(s.Subdir, "go.mod")}
+	if dir := s.majorDir(); dir != "" {
+		objects = append(objects, tag+":"+dir+"/go.mod")
+	}
+	return objects
+}
+
+// revision ubica el tag, directorio y go.mod de una versión ya validada a
+// partir de los objetos leídos con BareReadObjects
+func (s *Source) revision(version string, found map[string][]byte) (*revision, error) {
+	rev := &revision{Tag: s.tagRef(version), Dir: s.Subdir}
+
+	// Módulos /vN pueden estar en un subdirectorio vN (major subdirectory)
+	if dir := s.majorDir(); dir != "" {
+		if data, ok := found[rev.Tag+":"+dir+"/go.mod"]; ok && s.declares(data) {
+			rev.Dir = dir
+			rev.GoMod = data
+			return rev, nil
+		}
+	}
+
+	if _, ok := found[rev.Tag+"^{commit}"]; !ok {
+		return nil, fmt.Errorf("no existe el tag %s", strings.TrimPrefix(rev.Tag, "refs/tags/"))
+	}
+
+	data, ok := found[rev.Tag+":"+path.Join(rev.Dir, "go.mod")]
+	switch {
+	case ok && strings.HasSuffix(version, "+incompatible"):
+		return nil, fmt.Errorf("%s tiene go.mod; no puede usarse como +incompatible", version)
+	case ok && !s.declares(data):
+		return nil, fmt.Errorf("el go.mod de %s no declara module %s", version, s.Module)
+	case ok:
+		rev.GoMod = data
+	}
+
+	return rev, nil
+}
+
+// declares verifica que un go.mod declare el módulo de la fuente
+func (s *Source) declares(data []byte) bool {
+	f, err := gomod.ParseData(data)
+	return err == nil && f.Module == s.Module
+}
+
+// Info retorna el contenido JSON del archivo .info
+func (s *Source) Info(version string) ([]byte, error) {
+	rev, err := s.resolve(version)
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := git.BareCommitTime(s.GitDir, rev.Tag)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(Info{Version: version, Time: t.UTC()})
+}
+
+// GoMod retorna el go.mod de la versión (sintetizado si el módulo no tiene go.mod)
+func (s *Source) GoMod(version string) ([]byte, error) {
+	rev, err := s.resolve(version)
+	if err != nil {
+		return nil, err
+	}
+
+	if rev.GoMod == nil {
+		return []byte("module " + s.Module + "\n"), nil
+	}
+	return rev.GoMod, nil
+}
+
+// Zip escribe el zip del módulo para la versión
+func (s *Source) Zip(w io.Writer, version string) error {
+	rev, err := s.resolve(version)
+	if err != nil {
+		return err
+	}
+
+	data, err := git.BareArchive(s.GitDir, rev.Tag, rev.Dir)
+	if err != nil {
+		return err
+	}
+
+	files, err := filesFromTar(data, rev.Dir)
+	if err != nil {
+		return err
+	}
+
+	// Igual que el comando go: un módulo en subdirectorio sin LICENSE
+	// incluye el LICENSE de la raíz del repositorio
+	if rev.Dir != "" && !hasFile(files, "LICENSE") {
+		if license, ok, _ := git.BareReadFile(s.GitDir, rev.Tag, "LICENSE"); ok {
+			files = append(files, zipFile{Name: "LICENSE", Data: license})
+		}
+	}
+
+	return writeZip(w, s.Module, version, files)
+}
+
+func hasFile(files []zipFile, name string) bool {
+	for _, f := range files {
+		if f.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Build son los archivos de GOPROXY de una versión
+type Build struct {
+	Module  string
+	Version string
+	Info    []byte
+	Mod     []byte
+	Zip     []byte
+}
+
+// Build genera .info, .mod y .zip de una versión
+func (s *Source) Build(version string) (*Build, error) {
+	var zip bytes.Buffer
+	if err := s.Zip(&zip, version); err != nil {
+		return nil, err
+	}
+
+	info, err := s.Info(version)
+	if err != nil {
+		return nil, err
+	}
+
+	mod, err := s.GoMod(version)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Build{Module: s.Module, Version: version, Info: info, Mod: mod, Zip: zip.Bytes()}, nil
+}
@@ -0,0 +1,178 @@
+package modproxy
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/reitmas32/next/internal/sumdb"
+)
+
+// Líneas de go.sum calculadas por el comando go (golang.org/x/mod) para el
+// contenido de testRepoFiles como example.com/lib v1.0.0
+const (
+	knownZipSum   = "example.com/lib v1.0.0 h1:dfq7WLAV0JeFugJIh71aFHCDY1jaQHkbQsIgPjIOPwI="
+	knownGoModSum = "example.com/lib v1.0.0/go.mod h1:Dx1zv02UsdsagVg8JGP9CfaRr9j4IApAGUMhJrQ+NLw="
+)
+
+// testRepoFiles incluye reglas export-ignore y export-subst, que el comando
+// go ignora al generar el zip
+var testRepoFiles = map[string]string{
+	"go.mod":         "module example.com/lib\n\ngo 1.21\n",
+	"lib.go":         "package lib\n\n// Commit $Format:%H$\n\n// Hola saluda\nfunc Hola() string {\n\treturn \"hola\"\n}\n",
+	".gitattributes": "extra.txt export-ignore\n*.go export-subst\n",
+	"extra.txt":      "solo en el repositorio\n",
+	"LICENSE":        "MIT\n",
+}
+
+func TestSourceBuildMatchesGoSum(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git no está instalado")
+	}
+
+	tmp := t.TempDir()
+
+	// Un usuario con core.autocrlf=true no debe cambiar el contenido del zip
+	globalConfig := filepath.Join(tmp, "gitconfig")
+	if err := os.WriteFile(globalConfig, []byte("[core]\n\tautocrlf = true\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("GIT_CONFIG_GLOBAL", globalConfig)
+	t.Setenv("GIT_CONFIG_NOSYSTEM", "1")
+
+	repo := filepath.Join(tmp, "repo")
+	if err := os.MkdirAll(repo, 0700); err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range testRepoFiles {
+		if err := os.WriteFile(filepath.Join(repo, name), []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, args := range [][]string{
+		{"init", "--quiet"},
+		{"add", "."},
+		{"-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "--quiet", "-m", "inicial"},
+		{"tag", "v1.0.0"},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repo
+		if output, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, output)
+		}
+	}
+
+	src := &Source{
+		Module:  "example.com/lib",
+		RepoURL: repo,
+		GitDir:  filepath.Join(tmp, "cache", "lib.git"),
+	}
+	if err := src.Sync(); err != nil {
+		t.Fatal(err)
+	}
+
+	build, err := src.Build("v1.0.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	zipHash, err := sumdb.HashZip(build.Zip)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := "example.com/lib v1.0.0 " + zipHash; got != knownZipSum {
+		t.Errorf("hash del zip:\n got: %s\nwant: %s", got, knownZipSum)
+	}
+
+	modHash, err := sumdb.HashGoMod(build.Mod)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := "example.com/lib v1.0.0/go.mod " + modHash; got != knownGoModSum {
+		t.Errorf("hash del go.mod:\n got: %s\nwant: %s", got, knownGoModSum)
+	}
+}
+
+func TestSourceVersionsFiltersTags(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git no está instalado")
+	}
+
+	tmp := t.TempDir()
+	t.Setenv("GIT_CONFIG_GLOBAL", filepath.Join(tmp, "gitconfig"))
+	t.Setenv("GIT_CONFIG_NOSYSTEM", "1")
+
+	repo := filepath.Join(tmp, "repo")
+	git := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+		cmd.Dir = repo
+		if output, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, output)
+		}
+	}
+	write := func(name, content string) {
+		t.Helper()
+		path := filepath.Join(repo, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := os.MkdirAll(repo, 0700); err != nil {
+		t.Fatal(err)
+	}
+	git("init", "--quiet")
+	write("lib/go.mod", "module example.com/repo/lib\n")
+	write("lib/lib.go", "package lib\n")
+	git("add", ".")
+	git("commit", "--quiet", "-m", "v1")
+	git("tag", "lib/v1.0.0")
+	git("tag", "lib/v1.1.0-rc.1")
+	git("tag", "lib/v2.0.0") // el go.mod no tiene sufijo /v2: no es válida
+	git("tag", "lib/release")
+	git("tag", "v1.0.0") // otro módulo (la raíz)
+
+	write("lib/v2/go.mod", "module example.com/repo/lib/v2\n")
+	write("lib/v2/lib.go", "package lib\n")
+	git("add", ".")
+	git("commit", "--quiet", "-m", "v2")
+	git("tag", "lib/v2.1.0")
+
+	for _, tc := range []struct {
+		module string
+		want   []string
+	}{
+		{"example.com/repo/lib", []string{"v1.0.0", "v1.1.0-rc.1"}},
+		{"example.com/repo/lib/v2", []string{"v2.1.0"}},
+	} {
+		src := &Source{Module: tc.module, RepoURL: repo, Subdir: "lib", GitDir: filepath.Join(tmp, "cache", "repo.git")}
+		if err := src.Sync(); err != nil {
+			t.Fatal(err)
+		}
+
+		versions, err := src.Versions()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(versions, tc.want) {
+			t.Errorf("Versions de %s = %v, se esperaba %v", tc.module, versions, tc.want)
+		}
+	}
+
+	// resolve valida igual que Versions
+	src := &Source{Module: "example.com/repo/lib/v2", Subdir: "lib", GitDir: filepath.Join(tmp, "cache", "repo.git")}
+	rev, err := src.resolve("v2.1.0")
+	if err != nil || rev.Dir != "lib/v2" {
+		t.Fatalf("resolve(v2.1.0) = %+v, %v", rev, err)
+	}
+	if _, err := src.resolve("v2.2.0"); err == nil {
+		t.Fatal("resolve de un tag inexistente debería fallar")
+	}
+}
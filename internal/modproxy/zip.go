@@ -0,0 +1,88 @@
+package modproxy
+
+import (
+	"archive/tar"
+	"bytes"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"strings"
+	"time"
+
+	"golang.org/x/mod/module"
+	modzip "golang.org/x/mod/zip"
+)
+
+// zipFile es un archivo que se incluirá en el zip del módulo
+type zipFile struct {
+	Name string
+	Data []byte
+}
+
+// filesFromTar extrae los archivos de un tar de 'git archive', relativos a dir
+func filesFromTar(data []byte, dir string) ([]zipFile, error) {
+	prefix := ""
+	if dir != "" {
+		prefix = dir + "/"
+	}
+
+	var files []zipFile
+	tr := tar.NewReader(bytes.NewReader(data))
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error al leer archivo de git: %w", err)
+		}
+
+		// Solo archivos regulares: se omiten directorios y symlinks
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		if !strings.HasPrefix(hdr.Name, prefix) {
+			continue
+		}
+
+		content, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, fmt.Errorf("error al leer %s: %w", hdr.Name, err)
+		}
+		files = append(files, zipFile{Name: strings.TrimPrefix(hdr.Name, prefix), Data: content})
+	}
+
+	return files, nil
+}
+
+// Path, Lstat y Open implementan modzip.File
+func (f zipFile) Path() string { return f.Name }
+
+func (f zipFile) Lstat() (fs.FileInfo, error) { return zipFileInfo{f}, nil }
+
+func (f zipFile) Open() (io.ReadCloser, error) {
+	return io.NopCloser(bytes.NewReader(f.Data)), nil
+}
+
+// zipFileInfo describe un archivo regular extraído de git
+type zipFileInfo struct{ f zipFile }
+
+func (i zipFileInfo) Name() string       { return path.Base(i.f.Name) }
+func (i zipFileInfo) Size() int64        { return int64(len(i.f.Data)) }
+func (i zipFileInfo) Mode() fs.FileMode  { return 0644 }
+func (i zipFileInfo) ModTime() time.Time { return time.Time{} }
+func (i zipFileInfo) IsDir() bool        { return false }
+func (i zipFileInfo) Sys() any           { return nil }
+
+// writeZip escribe el zip del módulo con golang.org/x/mod/zip, que aplica las
+// mismas reglas que el comando go: omite submódulos (directorios con go.mod),
+// paquetes vendorizados y .hg_archival.txt, y valida nombres, mayúsculas y
+// tamaños máximos. Así el hash h1: coincide con el del comando go.
+func writeZip(w io.Writer, modulePath, version string, files []zipFile) error {
+	modFiles := make([]modzip.File, len(files))
+	for i, f := range files {
+		modFiles[i] = f
+	}
+	return modzip.Create(w, module.Version{Path: modulePath, Version: version}, modFiles)
+}
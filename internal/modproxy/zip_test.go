@@ -0,0 +1,61 @@
+package modproxy
+
+import (
+	"archive/zip"
+	"bytes"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestWriteZipAppliesGoCommandRules(t *testing.T) {
+	files := []zipFile{
+		{Name: "go.mod", Data: []byte("module example.com/lib\n")},
+		{Name: "lib.go", Data: []byte("package lib\n")},
+		{Name: "sub/go.mod", Data: []byte("module example.com/lib/sub\n")},
+		{Name: "sub/sub.go", Data: []byte("package sub\n")},
+		{Name: "vendor/modules.txt", Data: []byte("# vendor\n")},
+		{Name: "vendor/example.com/dep/dep.go", Data: []byte("package dep\n")},
+		{Name: ".hg_archival.txt", Data: []byte("repo: x\n")},
+	}
+
+	var buf bytes.Buffer
+	if err := writeZip(&buf, "example.com/lib", "v1.0.0", files); err != nil {
+		t.Fatal(err)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, f := range zr.File {
+		names = append(names, f.Name)
+	}
+	sort.Strings(names)
+
+	want := []string{
+		"example.com/lib@v1.0.0/go.mod",
+		"example.com/lib@v1.0.0/lib.go",
+		"example.com/lib@v1.0.0/vendor/modules.txt",
+	}
+	if !reflect.DeepEqual(names, want) {
+		t.Fatalf("archivos del zip:\n got: %v\nwant: %v", names, want)
+	}
+}
+
+func TestWriteZipRejectsInvalidModules(t *testing.T) {
+	for name, tc := range map[string]struct {
+		module, version string
+		files           []zipFile
+	}{
+		"mayúsculas":          {"example.com/lib", "v1.0.0", []zipFile{{Name: "README"}, {Name: "readme"}}},
+		"mayor sin sufijo":    {"example.com/lib", "v2.0.0", []zipFile{{Name: "go.mod"}}},
+		"versión no canónica": {"example.com/lib", "v1.0", []zipFile{{Name: "go.mod"}}},
+	} {
+		var buf bytes.Buffer
+		if err := writeZip(&buf, tc.module, tc.version, tc.files); err == nil {
+			t.Errorf("%s: se esperaba un error", name)
+		}
+	}
+}
@@ -0,0 +1,188 @@
+package publish
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/reitmas32/next/internal/modproxy"
+	"golang.org/x/mod/module"
+)
+
+// Publisher sube los archivos .info, .mod y .zip de una versión a un registro
+type Publisher interface {
+	// Name describe el destino (para mostrar al usuario)
+	Name() string
+	// Publish sube una versión generada
+	Publish(b *modproxy.Build) error
+}
+
+// DirPublisher escribe la versión en un directorio con el layout de GOPROXY
+// Sirve para pruebas y para registros que se sirven como archivos estáticos.
+type DirPublisher struct {
+	Root string
+}
+
+// NewDirPublisher crea un publisher a un directorio local
+func NewDirPublisher(root string) *DirPublisher {
+	return &DirPublisher{Root: root}
+}
+
+// Name describe el destino
+func (d *DirPublisher) Name() string {
+	return d.Root
+}
+
+// Publish escribe los archivos y agrega la versión al archivo list
+func (d *DirPublisher) Publish(b *modproxy.Build) error {
+	if err := modproxy.WriteBuild(d.Root, b); err != nil {
+		return err
+	}
+	return modproxy.AddToList(d.Root, b.Module, []string{b.Version})
+}
+
+// HTTPPublisher sube la versión con PUT a <URL>/<módulo>/@v/<versión>.<ext>
+// Es el layout que aceptan los repositorios Go locales de Artifactory y
+// cualquier registro que implemente el protocolo de GOPROXY con escritura.
+type HTTPPublisher struct {
+	URL string
+	// Authorization es el valor del header Authorization (opcional)
+	Authorization string
+
+	client *http.Client
+}
+
+// NewHTTPPublisher crea un publisher a un registro HTTP genérico
+func NewHTTPPublisher(baseURL, authorization string) *HTTPPublisher {
+	return &HTTPPublisher{
+		URL:           strings.TrimSuffix(baseURL, "/"),
+		Authorization: authorization,
+		client:        &http.Client{Timeout: 5 * time.Minute},
+	}
+}
+
+// Name describe el destino
+func (h *HTTPPublisher) Name() string {
+	return h.URL
+}
+
+// Publish sube .mod, .info y por último .zip
+func (h *HTTPPublisher) Publish(b *modproxy.Build) error {
+	escapedModule, err := module.EscapePath(b.Module)
+	if err != nil {
+		return err
+	}
+	escapedVersion, err := module.EscapeVersion(b.Version)
+	if err != nil {
+		return err
+	}
+	base := h.URL + "/" + escapedModule + "/@v/" + escapedVersion
+
+	files := []struct {
+		ext         string
+		data        []byte
+		contentType string
+	}{
+		{".mod", b.Mod, "text/plain; charset=utf-8"},
+		{".info", b.Info, "application/json"},
+		{".zip", b.Zip, "application/zip"},
+	}
+
+	for _, f := range files {
+		headers := map[string]string{"Content-Type": f.contentType}
+		if h.Authorization != "" {
+			headers["Authorization"] = h.Authorization
+		}
+		if err := put(h.client, base+f.ext, f.data, headers); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GitLabPublisher sube la versión al registro de paquetes genéricos de un
+// proyecto de GitLab. El proxy Go de GitLab genera los módulos a partir de
+// los tags del repositorio y no acepta subidas: el registro genérico guarda
+// los mismos .info/.mod/.zip para descargarlos o servirlos desde otro proxy.
+type GitLabPublisher struct {
+	BaseURL string
+	Project string
+	Token   string
+
+	client *http.Client
+}
+
+// NewGitLabPublisher crea un publisher al registro de un proyecto de GitLab
+func NewGitLabPublisher(baseURL, project, token string) *GitLabPublisher {
+	return &GitLabPublisher{
+		BaseURL: strings.TrimSuffix(baseURL, "/"),
+		Project: project,
+		Token:   token,
+		client:  &http.Client{Timeout: 5 * time.Minute},
+	}
+}
+
+// Name describe el destino
+func (g *GitLabPublisher) Name() string {
+	return fmt.Sprintf("%s/%s (paquetes genéricos)", g.BaseURL, g.Project)
+}
+
+// Publish sube los archivos como el paquete <módulo sanitizado>/<versión>
+func (g *GitLabPublisher) Publish(b *modproxy.Build) error {
+	base := fmt.Sprintf("%s/api/v4/projects/%s/packages/generic/%s/%s/",
+		g.BaseURL, url.PathEscape(g.Project), PackageName(b.Module), url.PathEscape(b.Version))
+
+	files := []struct {
+		ext  string
+		data []byte
+	}{
+		{".mod", b.Mod},
+		{".info", b.Info},
+		{".zip", b.Zip},
+	}
+
+	for _, f := range files {
+		headers := map[string]string{"PRIVATE-TOKEN": g.Token}
+		if err := put(g.client, base+url.PathEscape(b.Version+f.ext), f.data, headers); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// invalidPackageChars son los caracteres no permitidos en nombres de paquetes genéricos
+var invalidPackageChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// PackageName convierte un module path en un nombre de paquete genérico de GitLab
+// Ejemplo: "gitlab.com/org/lib/v2" -> "gitlab.com-org-lib-v2"
+func PackageName(module string) string {
+	return invalidPackageChars.ReplaceAllString(module, "-")
+}
+
+// put sube un archivo y falla si el servidor no responde 2xx
+func put(client *http.Client, target string, data []byte, headers map[string]string) error {
+	req, err := http.NewRequest(http.MethodPut, target, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("error de conexión: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return fmt.Errorf("error al subir %s (status: %d): %s", target, resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
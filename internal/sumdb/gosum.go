@@ -0,0 +1,43 @@
+package sumdb
+
+import (
+	"bufio"
+	"os"
+	"strings"
+)
+
+// Sum es una línea de go.sum
+type Sum struct {
+	Module  string
+	Version string
+	// GoMod indica una línea "module version/go.mod h1:..."
+	GoMod bool
+	Hash  string
+}
+
+// ParseGoSum lee un archivo go.sum (o go.work.sum)
+func ParseGoSum(path string) ([]Sum, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var sums []Sum
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 3 {
+			continue
+		}
+
+		sum := Sum{Module: fields[0], Version: fields[1], Hash: fields[2]}
+		if strings.HasSuffix(sum.Version, "/go.mod") {
+			sum.Version = strings.TrimSuffix(sum.Version, "/go.mod")
+			sum.GoMod = true
+		}
+		sums = append(sums, sum)
+	}
+
+	return sums, scanner.Err()
+}
@@ -0,0 +1,42 @@
+package sumdb
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+
+	"golang.org/x/mod/sumdb/dirhash"
+)
+
+// HashZip calcula el hash h1: de un zip de módulo (línea "module version h1:..." de go.sum)
+// con dirhash.Hash1, el mismo cálculo que dirhash.HashZip sin escribir el zip a disco
+func HashZip(data []byte) (string, error) {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return "", fmt.Errorf("zip inválido: %w", err)
+	}
+
+	files := make([]string, 0, len(zr.File))
+	entries := make(map[string]*zip.File, len(zr.File))
+	for _, f := range zr.File {
+		files = append(files, f.Name)
+		entries[f.Name] = f
+	}
+
+	return dirhash.Hash1(files, func(name string) (io.ReadCloser, error) {
+		f, ok := entries[name]
+		if !ok {
+			return nil, os.ErrNotExist
+		}
+		return f.Open()
+	})
+}
+
+// HashGoMod calcula el hash h1: de un go.mod (línea "module version/go.mod h1:..." de go.sum)
+func HashGoMod(data []byte) (string, error) {
+	return dirhash.Hash1([]string{"go.mod"}, func(string) (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(data)), nil
+	})
+}
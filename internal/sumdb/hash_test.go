@@ -0,0 +1,58 @@
+package sumdb
+
+import (
+	"archive/zip"
+	"bytes"
+	"testing"
+)
+
+// Hashes de go.sum publicados para módulos conocidos (sum.golang.org)
+const (
+	// rsc.io/quote v1.5.2/go.mod
+	quoteGoModSum = "h1:LzX7hefJvL54yjefDEDHNONDjII0t9xZLPXsUe+TKr0="
+	quoteGoMod    = "module \"rsc.io/quote\"\n\nrequire \"rsc.io/sampler\" v1.3.0\n"
+)
+
+func TestHashGoModMatchesGoSum(t *testing.T) {
+	got, err := HashGoMod([]byte(quoteGoMod))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != quoteGoModSum {
+		t.Fatalf("HashGoMod = %s, se esperaba %s", got, quoteGoModSum)
+	}
+}
+
+func TestHashZipIgnoresEntryOrder(t *testing.T) {
+	build := func(names ...string) []byte {
+		var buf bytes.Buffer
+		zw := zip.NewWriter(&buf)
+		for _, name := range names {
+			w, err := zw.Create("example.com/lib@v1.0.0/" + name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			w.Write([]byte(name + "\n"))
+		}
+		if err := zw.Close(); err != nil {
+			t.Fatal(err)
+		}
+		return buf.Bytes()
+	}
+
+	a, err := HashZip(build("go.mod", "lib.go", "LICENSE"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := HashZip(build("LICENSE", "lib.go", "go.mod"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a != b {
+		t.Fatalf("el orden de los archivos cambió el hash: %s != %s", a, b)
+	}
+
+	if _, err := HashZip([]byte("no es un zip")); err == nil {
+		t.Fatal("se esperaba un error con un zip inválido")
+	}
+}
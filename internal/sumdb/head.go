@@ -0,0 +1,126 @@
+package sumdb
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/reitmas32/next/internal/config"
+)
+
+const ledgerHeadsFile = "ledger_heads.json"
+
+// Head es la cabecera firmada de un ledger: la cantidad de registros y el
+// hash de la última línea la última vez que el usuario agregó un registro.
+// La cadena de hashes no detecta registros eliminados del final; la cabecera
+// sí, porque se guarda fuera del directorio de cualquier ledger.
+type Head struct {
+	Count     int    `json:"count"`
+	Last      string `json:"last"`
+	Key       string `json:"key"`
+	Signature string `json:"sig"`
+}
+
+// LedgerHeadsPath retorna el archivo de cabeceras (~/.next/ledger_heads.json),
+// junto a trusted_keys
+func LedgerHeadsPath() (string, error) {
+	dir, err := config.GetConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, ledgerHeadsFile), nil
+}
+
+// headKey identifica un ledger por su ruta absoluta
+func headKey(ledger string) string {
+	if abs, err := filepath.Abs(ledger); err == nil {
+		return abs
+	}
+	return ledger
+}
+
+// payload retorna el contenido firmado de la cabecera; incluye la ruta del
+// ledger para que la cabecera de un ledger no sirva para otro
+func (h *Head) payload(ledger string) []byte {
+	return []byte(strings.Join([]string{headKey(ledger), strconv.Itoa(h.Count), h.Last}, "\n"))
+}
+
+// loadHeads lee todas las cabeceras (vacío si el archivo no existe)
+func loadHeads() (map[string]Head, error) {
+	heads := make(map[string]Head)
+
+	path, err := LedgerHeadsPath()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return heads, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error al leer cabeceras de ledger: %w", err)
+	}
+	if err := json.Unmarshal(data, &heads); err != nil {
+		return nil, fmt.Errorf("error al parsear %s: %w", path, err)
+	}
+	return heads, nil
+}
+
+// loadHead retorna la cabecera de un ledger (nil si no tiene) validando su
+// firma con las llaves confiables
+func loadHead(ledger string, trusted map[string]bool) (*Head, error) {
+	heads, err := loadHeads()
+	if err != nil {
+		return nil, err
+	}
+
+	head, ok := heads[headKey(ledger)]
+	if !ok {
+		return nil, nil
+	}
+
+	if !trusted[head.Key] {
+		return nil, fmt.Errorf("la cabecera del ledger %s está firmada con una llave no confiable (%s)", ledger, head.Key)
+	}
+	pub, err := parsePublicKey(head.Key)
+	if err != nil {
+		return nil, err
+	}
+	sig, err := base64.StdEncoding.DecodeString(head.Signature)
+	if err != nil || !ed25519.Verify(pub, head.payload(ledger), sig) {
+		return nil, fmt.Errorf("firma inválida en la cabecera del ledger %s", ledger)
+	}
+	return &head, nil
+}
+
+// saveHead firma y guarda la cabecera de un ledger
+func saveHead(ledger string, count int, last string, key ed25519.PrivateKey) error {
+	heads, err := loadHeads()
+	if err != nil {
+		return err
+	}
+
+	head := Head{Count: count, Last: last, Key: PublicKeyString(key.Public().(ed25519.PublicKey))}
+	head.Signature = base64.StdEncoding.EncodeToString(ed25519.Sign(key, head.payload(ledger)))
+	heads[headKey(ledger)] = head
+
+	data, err := json.MarshalIndent(heads, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	path, err := LedgerHeadsPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0600)
+}
@@ -0,0 +1,176 @@
+package sumdb
+
+import (
+	"bufio"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/reitmas32/next/internal/config"
+	"github.com/reitmas32/next/internal/crypto"
+)
+
+const (
+	signingKeyFile  = "signing.key"
+	trustedKeysFile = "trusted_keys"
+	keyPrefix       = "ed25519:"
+)
+
+// getSumDBDir retorna el directorio ~/.next/sumdb
+func getSumDBDir() (string, error) {
+	dir, err := config.GetConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "sumdb"), nil
+}
+
+// LoadOrCreateKey obtiene la llave de firma del usuario o crea una nueva.
+// La llave privada se guarda encriptada con la misma llave que los tokens.
+func LoadOrCreateKey() (ed25519.PrivateKey, error) {
+	dir, err := getSumDBDir()
+	if err != nil {
+		return nil, err
+	}
+	keyPath := filepath.Join(dir, signingKeyFile)
+
+	encryptionKey, err := crypto.GetOrCreateKey()
+	if err != nil {
+		return nil, fmt.Errorf("error obteniendo llave de encriptación: %w", err)
+	}
+
+	data, err := os.ReadFile(keyPath)
+	if err == nil {
+		seed, err := crypto.Decrypt(strings.TrimSpace(string(data)), encryptionKey)
+		if err != nil {
+			return nil, fmt.Errorf("error al desencriptar llave de firma: %w", err)
+		}
+		raw, err := base64.StdEncoding.DecodeString(seed)
+		if err != nil || len(raw) != ed25519.SeedSize {
+			return nil, fmt.Errorf("llave de firma inválida: %s", keyPath)
+		}
+		return ed25519.NewKeyFromSeed(raw), nil
+	}
+	if !os.IsNotExist(err) {
+		return nil, fmt.Errorf("error al leer llave de firma: %w", err)
+	}
+
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("error generando llave de firma: %w", err)
+	}
+
+	encrypted, err := crypto.Encrypt(base64.StdEncoding.EncodeToString(priv.Seed()), encryptionKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("error al crear directorio de sumdb: %w", err)
+	}
+	if err := os.WriteFile(keyPath, []byte(encrypted+"\n"), 0600); err != nil {
+		return nil, fmt.Errorf("error al guardar llave de firma: %w", err)
+	}
+
+	return priv, nil
+}
+
+// PublicKeyString codifica una llave pública como "ed25519:<base64>"
+func PublicKeyString(pub ed25519.PublicKey) string {
+	return keyPrefix + base64.StdEncoding.EncodeToString(pub)
+}
+
+// parsePublicKey decodifica una llave pública "ed25519:<base64>"
+func parsePublicKey(s string) (ed25519.PublicKey, error) {
+	raw, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(s, keyPrefix))
+	if err != nil || !strings.HasPrefix(s, keyPrefix) || len(raw) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("llave pública inválida: %s", s)
+	}
+	return ed25519.PublicKey(raw), nil
+}
+
+// TrustedKeysPath retorna el archivo de llaves confiables (~/.next/trusted_keys).
+// Está fuera del directorio de cualquier ledger: quien puede escribir en un
+// ledger compartido no puede además declarar confiable su propia llave.
+func TrustedKeysPath() (string, error) {
+	dir, err := config.GetConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, trustedKeysFile), nil
+}
+
+// TrustedKeys retorna las llaves públicas confiables del usuario (una por
+// línea en TrustedKeysPath, # para comentarios)
+func TrustedKeys() (map[string]bool, error) {
+	trusted := make(map[string]bool)
+
+	path, err := TrustedKeysPath()
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return trusted, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key := strings.Fields(line)[0]
+		if _, err := parsePublicKey(key); err != nil {
+			return nil, err
+		}
+		trusted[key] = true
+	}
+
+	return trusted, scanner.Err()
+}
+
+// TrustKey agrega una llave pública a las llaves confiables del usuario.
+// Retorna false si ya era confiable.
+func TrustKey(key, comment string) (bool, error) {
+	if _, err := parsePublicKey(key); err != nil {
+		return false, err
+	}
+
+	trusted, err := TrustedKeys()
+	if err != nil || trusted[key] {
+		return false, err
+	}
+
+	path, err := TrustedKeysPath()
+	if err != nil {
+		return false, err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return false, err
+	}
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return false, err
+	}
+	defer file.Close()
+
+	line := key
+	if comment != "" {
+		line += " # " + comment
+	}
+	if _, err := fmt.Fprintln(file, line); err != nil {
+		return false, err
+	}
+	return true, nil
+}
@@ -0,0 +1,225 @@
+package sumdb
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const ledgerFile = "ledger.jsonl"
+
+// Record es una entrada del ledger: los hashes de una versión publicada.
+// Cada registro incluye el hash de la línea anterior (cadena append-only) y
+// la firma ed25519 de quien lo registró.
+type Record struct {
+	Module    string    `json:"module"`
+	Version   string    `json:"version"`
+	Hash      string    `json:"h1"`
+	GoModHash string    `json:"gomod_h1"`
+	Time      time.Time `json:"time"`
+	Prev      string    `json:"prev"`
+	Key       string    `json:"key"`
+	Signature string    `json:"sig"`
+}
+
+// Ledger es un archivo JSON Lines de registros firmados
+type Ledger struct {
+	Path    string
+	Records []Record
+	// last es el hash de la última línea del archivo
+	last string
+	// trusted son las llaves aceptadas para leer y agregar registros
+	trusted map[string]bool
+}
+
+// DefaultLedgerPath retorna ~/.next/sumdb/ledger.jsonl
+func DefaultLedgerPath() (string, error) {
+	dir, err := getSumDBDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, ledgerFile), nil
+}
+
+// payload retorna el contenido firmado del registro
+func (r *Record) payload() []byte {
+	return []byte(strings.Join([]string{
+		r.Module, r.Version, r.Hash, r.GoModHash, r.Time.UTC().Format(time.RFC3339), r.Prev,
+	}, "\n"))
+}
+
+// verify valida la firma del registro. La llave que declara el registro
+// debe estar entre las confiables: si no, cualquiera con acceso de escritura
+// al ledger podría firmar registros con una llave propia.
+func (r *Record) verify(trusted map[string]bool) error {
+	if !trusted[r.Key] {
+		return fmt.Errorf("%s@%s está firmado con una llave no confiable (%s); use 'next trust-key' si la reconoce", r.Module, r.Version, r.Key)
+	}
+
+	pub, err := parsePublicKey(r.Key)
+	if err != nil {
+		return err
+	}
+	sig, err := base64.StdEncoding.DecodeString(r.Signature)
+	if err != nil || !ed25519.Verify(pub, r.payload(), sig) {
+		return fmt.Errorf("firma inválida para %s@%s", r.Module, r.Version)
+	}
+	return nil
+}
+
+// lineHash retorna el hash de una línea del ledger
+func lineHash(line []byte) string {
+	sum := sha256.Sum256(line)
+	return hex.EncodeToString(sum[:])
+}
+
+// OpenLedger lee el ledger (vacío si no existe) y valida la cadena de hashes,
+// las firmas con las llaves confiables y la cabecera firmada del usuario. Un
+// ledger modificado, reordenado, truncado o con registros de llaves no
+// confiables retorna error. Los finales de línea CRLF (ej: git con autocrlf)
+// se normalizan antes de calcular los hashes.
+func OpenLedger(path string, trusted map[string]bool) (*Ledger, error) {
+	l := &Ledger{Path: path, trusted: trusted}
+
+	head, err := loadHead(path, trusted)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return l, l.checkHead(head, "")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error al leer ledger: %w", err)
+	}
+	defer file.Close()
+
+	// atHead es el hash de la línea en la posición de la cabecera
+	atHead := ""
+	scanner := bufio.NewScanner(file)
+	n := 0
+	for scanner.Scan() {
+		n++
+		line := bytes.TrimSuffix(scanner.Bytes(), []byte("\r"))
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+
+		var r Record
+		if err := json.Unmarshal(line, &r); err != nil {
+			return nil, fmt.Errorf("ledger %s línea %d: %w", path, n, err)
+		}
+		if r.Prev != l.last {
+			return nil, fmt.Errorf("ledger %s línea %d: la cadena de hashes no coincide (el ledger fue modificado)", path, n)
+		}
+		if err := r.verify(l.trusted); err != nil {
+			return nil, fmt.Errorf("ledger %s línea %d: %w", path, n, err)
+		}
+
+		l.Records = append(l.Records, r)
+		l.last = lineHash(line)
+		if head != nil && len(l.Records) == head.Count {
+			atHead = l.last
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error al leer ledger: %w", err)
+	}
+	if err := l.checkHead(head, atHead); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// checkHead compara el ledger con su cabecera: puede tener registros nuevos
+// (ej: agregados por otros en un ledger compartido) pero no menos, y la línea
+// en la posición de la cabecera debe ser la misma
+func (l *Ledger) checkHead(head *Head, atHead string) error {
+	switch {
+	case head == nil:
+		return nil
+	case len(l.Records) < head.Count:
+		return fmt.Errorf("ledger %s: tiene %d registros pero su cabecera firmada registra %d (se eliminaron registros)", l.Path, len(l.Records), head.Count)
+	case atHead != head.Last:
+		return fmt.Errorf("ledger %s: el registro %d no coincide con la cabecera firmada (el ledger fue reemplazado)", l.Path, head.Count)
+	}
+	return nil
+}
+
+// Lookup retorna los registros de una versión (más de uno solo si hubo conflicto)
+func (l *Ledger) Lookup(module, version string) []Record {
+	var records []Record
+	for _, r := range l.Records {
+		if r.Module == module && r.Version == version {
+			records = append(records, r)
+		}
+	}
+	return records
+}
+
+// Append firma y agrega un registro y actualiza la cabecera firmada del
+// ledger. Si la versión ya está registrada con los mismos hashes no hace
+// nada; si los hashes difieren retorna error (el tag fue movido) y no
+// modifica el ledger. La llave debe ser confiable.
+func (l *Ledger) Append(module, version, hash, goModHash string, key ed25519.PrivateKey) (bool, error) {
+	publicKey := PublicKeyString(key.Public().(ed25519.PublicKey))
+	if !l.trusted[publicKey] {
+		return false, fmt.Errorf("la llave de firma %s no es confiable; ejecute 'next trust-key' para confiar en ella", publicKey)
+	}
+
+	for _, r := range l.Lookup(module, version) {
+		if r.Hash == hash && r.GoModHash == goModHash {
+			return false, nil
+		}
+		return false, fmt.Errorf("%s@%s ya está registrado con otro hash (%s); el tag pudo haber sido movido", module, version, r.Hash)
+	}
+
+	r := Record{
+		Module:    module,
+		Version:   version,
+		Hash:      hash,
+		GoModHash: goModHash,
+		Time:      time.Now().UTC().Truncate(time.Second),
+		Prev:      l.last,
+		Key:       publicKey,
+	}
+	r.Signature = base64.StdEncoding.EncodeToString(ed25519.Sign(key, r.payload()))
+
+	line, err := json.Marshal(r)
+	if err != nil {
+		return false, err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(l.Path), 0755); err != nil {
+		return false, fmt.Errorf("error al crear directorio del ledger: %w", err)
+	}
+
+	file, err := os.OpenFile(l.Path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return false, fmt.Errorf("error al abrir ledger: %w", err)
+	}
+	defer file.Close()
+
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		return false, fmt.Errorf("error al escribir ledger: %w", err)
+	}
+
+	l.Records = append(l.Records, r)
+	l.last = lineHash(line)
+
+	if err := saveHead(l.Path, len(l.Records), l.last, key); err != nil {
+		return true, fmt.Errorf("registro agregado, pero no se pudo guardar la cabecera del ledger: %w", err)
+	}
+	return true, nil
+}
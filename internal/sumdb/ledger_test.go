@@ -0,0 +1,162 @@
+package sumdb
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func newTestKey(t *testing.T) (ed25519.PrivateKey, string) {
+	t.Helper()
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return priv, PublicKeyString(pub)
+}
+
+func TestLedgerRequiresTrustedKeys(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	path := filepath.Join(t.TempDir(), "ledger.jsonl")
+	own, ownPublic := newTestKey(t)
+	other, otherPublic := newTestKey(t)
+	trusted := map[string]bool{ownPublic: true}
+
+	l, err := OpenLedger(path, trusted)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := l.Append("example.com/lib", "v1.0.0", "h1:a", "h1:b", own); err != nil {
+		t.Fatalf("Append con llave confiable: %v", err)
+	}
+	if _, err := l.Append("example.com/lib", "v1.1.0", "h1:c", "h1:d", other); err == nil {
+		t.Fatal("Append con llave no confiable debería fallar")
+	}
+
+	if records := l.Lookup("example.com/lib", "v1.0.0"); len(records) != 1 || records[0].Key != ownPublic {
+		t.Fatalf("Lookup: %+v", records)
+	}
+
+	// Un registro firmado por otra llave (ej: agregado por quien puede
+	// escribir el ledger compartido) invalida el ledger para quien no confía en ella
+	l, err = OpenLedger(path, map[string]bool{ownPublic: true, otherPublic: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := l.Append("example.com/lib", "v1.1.0", "h1:c", "h1:d", other); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := OpenLedger(path, trusted); err == nil || !strings.Contains(err.Error(), "no confiable") {
+		t.Fatalf("OpenLedger con un registro de llave no confiable: %v", err)
+	}
+}
+
+// newTestLedger crea un ledger con tres registros firmados por una llave confiable
+func newTestLedger(t *testing.T) (string, map[string]bool, ed25519.PrivateKey) {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+
+	path := filepath.Join(t.TempDir(), "ledger.jsonl")
+	key, public := newTestKey(t)
+	trusted := map[string]bool{public: true}
+
+	l, err := OpenLedger(path, trusted)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, v := range []string{"v1.0.0", "v1.1.0", "v1.2.0"} {
+		if _, err := l.Append("example.com/lib", v, "h1:"+v, "h1:mod"+v, key); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return path, trusted, key
+}
+
+func TestLedgerDetectsTruncation(t *testing.T) {
+	path, trusted, _ := newTestLedger(t)
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := bytes.SplitAfter(data, []byte("\n"))
+
+	// Eliminar el último registro deja una cadena de hashes válida
+	if err := os.WriteFile(path, bytes.Join(lines[:2], nil), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := OpenLedger(path, trusted); err == nil || !strings.Contains(err.Error(), "se eliminaron registros") {
+		t.Fatalf("OpenLedger con el ledger truncado: %v", err)
+	}
+
+	// Borrar el ledger completo también se detecta
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := OpenLedger(path, trusted); err == nil {
+		t.Fatal("OpenLedger sin el archivo del ledger debería fallar")
+	}
+}
+
+func TestLedgerAcceptsRecordsAddedByOthers(t *testing.T) {
+	path, trusted, _ := newTestLedger(t)
+
+	// Otro usuario (llave confiable) agrega un registro al ledger compartido;
+	// su cabecera no se guarda en el HOME de este usuario
+	other, otherPublic := newTestKey(t)
+	trusted[otherPublic] = true
+	heads, _ := LedgerHeadsPath()
+	saved, err := os.ReadFile(heads)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	l, err := OpenLedger(path, trusted)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := l.Append("example.com/lib", "v1.3.0", "h1:c", "h1:d", other); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(heads, saved, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	l, err = OpenLedger(path, trusted)
+	if err != nil {
+		t.Fatalf("OpenLedger con registros nuevos: %v", err)
+	}
+	if len(l.Records) != 4 {
+		t.Fatalf("se leyeron %d registros, se esperaban 4", len(l.Records))
+	}
+}
+
+func TestLedgerNormalizesCRLF(t *testing.T) {
+	path, trusted, key := newTestLedger(t)
+
+	// Un checkout de git con autocrlf convierte los finales de línea
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, bytes.ReplaceAll(data, []byte("\n"), []byte("\r\n")), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	l, err := OpenLedger(path, trusted)
+	if err != nil {
+		t.Fatalf("OpenLedger con CRLF: %v", err)
+	}
+	if _, err := l.Append("example.com/lib", "v1.3.0", "h1:c", "h1:d", key); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := OpenLedger(path, trusted); err != nil {
+		t.Fatalf("OpenLedger después de agregar a un ledger con CRLF: %v", err)
+	}
+}
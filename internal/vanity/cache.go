@@ -0,0 +1,177 @@
+package vanity
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/reitmas32/next/internal/config"
+)
+
+const cacheFile = "vanity.json"
+
+// TTL es el tiempo que se reutiliza una resolución antes de volver a consultarla
+var TTL = 24 * time.Hour
+
+// ErrorTTL es el tiempo que se reutiliza un error de red (ej: un dominio que
+// no responde) para no esperar el timeout en cada ejecución
+var ErrorTTL = time.Hour
+
+// resolveWorkers es la cantidad de consultas simultáneas de ResolveAll
+const resolveWorkers = 8
+
+// cacheEntry guarda una resolución; Import nil significa que el path no es
+// vanity. Error guarda un error de red (se reutiliza durante ErrorTTL).
+type cacheEntry struct {
+	Import    *Import   `json:"import,omitempty"`
+	Error     string    `json:"error,omitempty"`
+	CheckedAt time.Time `json:"checked_at"`
+}
+
+// fresh indica si la entrada sigue vigente
+func (e cacheEntry) fresh() bool {
+	if e.Error != "" {
+		return time.Since(e.CheckedAt) < ErrorTTL
+	}
+	return time.Since(e.CheckedAt) < TTL
+}
+
+// result retorna la resolución guardada
+func (e cacheEntry) result() (*Import, error) {
+	if e.Error != "" {
+		return nil, errors.New(e.Error)
+	}
+	return e.Import, nil
+}
+
+var (
+	cacheMu sync.Mutex
+	cache   map[string]cacheEntry
+	// inflight evita consultar dos veces el mismo path en paralelo
+	inflight = make(map[string]chan struct{})
+)
+
+// getCachePath retorna la ruta de ~/.next/cache/vanity.json
+func getCachePath() (string, error) {
+	dir, err := config.GetConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "cache", cacheFile), nil
+}
+
+// loadCache carga el cache una sola vez por proceso
+func loadCache() map[string]cacheEntry {
+	if cache != nil {
+		return cache
+	}
+
+	cache = make(map[string]cacheEntry)
+	cachePath, err := getCachePath()
+	if err != nil {
+		return cache
+	}
+
+	data, err := os.ReadFile(cachePath)
+	if err != nil {
+		return cache
+	}
+
+	// Un cache corrupto se descarta
+	_ = json.Unmarshal(data, &cache)
+	return cache
+}
+
+// saveCache guarda el cache en disco; los errores se ignoran porque el cache es opcional
+func saveCache() {
+	cachePath, err := getCachePath()
+	if err != nil {
+		return
+	}
+
+	if err := os.MkdirAll(filepath.Dir(cachePath), 0700); err != nil {
+		return
+	}
+
+	data, err := json.MarshalIndent(cache, "", "  ")
+	if err != nil {
+		return
+	}
+
+	_ = os.WriteFile(cachePath, data, 0600)
+}
+
+// Lookup retorna la resolución guardada en el cache sin importar su edad y
+// sin consultar la red (para el modo offline)
+func Lookup(importPath string) (*Import, bool) {
+	cacheMu.Lock()
+	defer cacheMu.Unlock()
+
+	entry, ok := loadCache()[importPath]
+	if !ok || entry.Error != "" {
+		return nil, false
+	}
+	return entry.Import, true
+}
+
+// Resolve resuelve un import path vanity usando el cache local
+// Retorna nil sin error si el path no declara go-import. Los errores de red
+// también se guardan (durante ErrorTTL) para no repetir el timeout.
+func Resolve(importPath string) (*Import, error) {
+	for {
+		cacheMu.Lock()
+		if entry, ok := loadCache()[importPath]; ok && entry.fresh() {
+			cacheMu.Unlock()
+			return entry.result()
+		}
+
+		// Otra goroutine ya está consultando este path: esperar su resultado
+		if wait, ok := inflight[importPath]; ok {
+			cacheMu.Unlock()
+			<-wait
+			continue
+		}
+
+		done := make(chan struct{})
+		inflight[importPath] = done
+		cacheMu.Unlock()
+
+		// La consulta se hace sin el lock para no serializar dominios distintos
+		imp, err := Fetch(importPath)
+
+		entry := cacheEntry{Import: imp, CheckedAt: time.Now()}
+		if err != nil {
+			entry.Error = err.Error()
+		}
+
+		cacheMu.Lock()
+		loadCache()[importPath] = entry
+		saveCache()
+		delete(inflight, importPath)
+		cacheMu.Unlock()
+		close(done)
+
+		return imp, err
+	}
+}
+
+// ResolveAll resuelve varios import paths en paralelo y deja el resultado en
+// el cache, para que las llamadas siguientes a Resolve no esperen una por una
+func ResolveAll(importPaths []string) {
+	sem := make(chan struct{}, resolveWorkers)
+	var wg sync.WaitGroup
+	for _, importPath := range importPaths {
+		wg.Add(1)
+		go func(importPath string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			_, _ = Resolve(importPath)
+		}(importPath)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,100 @@
+package vanity
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// roundTripFunc permite reemplazar el transporte del cliente en los tests
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// useTestClient reemplaza el cliente HTTP y el cache del paquete durante el test
+func useTestClient(t *testing.T, fn roundTripFunc) {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+
+	oldClient := client
+	client = &http.Client{Transport: fn}
+	cache = nil
+	t.Cleanup(func() {
+		client = oldClient
+		cache = nil
+	})
+}
+
+func TestResolveCachesNetworkErrors(t *testing.T) {
+	var calls atomic.Int32
+	useTestClient(t, func(req *http.Request) (*http.Response, error) {
+		calls.Add(1)
+		return nil, errors.New("timeout")
+	})
+
+	if _, err := Resolve("go.caido.dev/lib"); err == nil {
+		t.Fatal("se esperaba un error de red")
+	}
+	if _, err := Resolve("go.caido.dev/lib"); err == nil || !strings.Contains(err.Error(), "timeout") {
+		t.Fatalf("el error debería venir del cache: %v", err)
+	}
+	if n := calls.Load(); n != 1 {
+		t.Fatalf("se hicieron %d consultas, se esperaba 1", n)
+	}
+
+	// Vencido ErrorTTL se vuelve a consultar
+	cacheMu.Lock()
+	entry := cache["go.caido.dev/lib"]
+	entry.CheckedAt = time.Now().Add(-ErrorTTL - time.Minute)
+	cache["go.caido.dev/lib"] = entry
+	cacheMu.Unlock()
+
+	_, _ = Resolve("go.caido.dev/lib")
+	if n := calls.Load(); n != 2 {
+		t.Fatalf("se hicieron %d consultas, se esperaban 2", n)
+	}
+}
+
+func TestResolveAllFetchesInParallelOncePerPath(t *testing.T) {
+	var calls, active, peak atomic.Int32
+	useTestClient(t, func(req *http.Request) (*http.Response, error) {
+		calls.Add(1)
+		n := active.Add(1)
+		defer active.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(50 * time.Millisecond)
+
+		importPath := req.URL.Host + req.URL.Path
+		body := `<meta name="go-import" content="` + importPath + ` git https://gitlab.com/empresa/` + req.URL.Host + `.git">`
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(body)), Request: req}, nil
+	})
+
+	paths := []string{"go.a.dev/lib", "go.b.dev/lib", "go.c.dev/lib", "go.a.dev/lib", "go.a.dev/lib"}
+	ResolveAll(paths)
+
+	if n := calls.Load(); n != 3 {
+		t.Fatalf("se hicieron %d consultas, se esperaban 3", n)
+	}
+	if peak.Load() < 2 {
+		t.Fatal("las consultas de dominios distintos no se hicieron en paralelo")
+	}
+
+	imp, err := Resolve("go.b.dev/lib")
+	if err != nil || imp == nil || imp.Location() != "gitlab.com/empresa/go.b.dev" {
+		t.Fatalf("Resolve desde el cache: %+v, %v", imp, err)
+	}
+	if n := calls.Load(); n != 3 {
+		t.Fatalf("Resolve no debería consultar de nuevo (%d consultas)", n)
+	}
+}
@@ -0,0 +1,199 @@
+package vanity
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Import es el resultado de resolver un import path con las meta tags
+// go-import y go-source (el mismo mecanismo que usa el comando go)
+type Import struct {
+	// Prefix es el prefijo del import path que corresponde a la raíz del repositorio
+	Prefix string `json:"prefix"`
+	// VCS es el sistema de control de versiones (git, hg, mod, ...)
+	VCS string `json:"vcs"`
+	// RepoURL es la URL real del repositorio
+	RepoURL string `json:"repo_url"`
+	// SourceHome es la URL de go-source (opcional)
+	SourceHome string `json:"source_home,omitempty"`
+}
+
+// Hosts de código conocidos: el comando go no consulta meta tags para ellos
+var knownHosts = map[string]bool{
+	"github.com":    true,
+	"gitlab.com":    true,
+	"bitbucket.org": true,
+}
+
+// Dominios vanity públicos muy usados: nunca alojan módulos privados, así que
+// no vale la pena consultar sus meta tags para buscar una cuenta
+var publicHosts = map[string]bool{
+	"golang.org":          true,
+	"google.golang.org":   true,
+	"cloud.google.com":    true,
+	"gopkg.in":            true,
+	"go.uber.org":         true,
+	"go.opentelemetry.io": true,
+	"go.etcd.io":          true,
+	"go.mongodb.org":      true,
+	"k8s.io":              true,
+	"sigs.k8s.io":         true,
+	"gotest.tools":        true,
+	"honnef.co":           true,
+	"mvdan.cc":            true,
+	"gorm.io":             true,
+	"gonum.org":           true,
+	"modernc.org":         true,
+	"filippo.io":          true,
+	"dario.cat":           true,
+}
+
+var (
+	metaPattern = regexp.MustCompile(`(?is)<meta\s[^>]*>`)
+	attrPattern = regexp.MustCompile(`(?is)([a-z-]+)\s*=\s*(?:"([^"]*)"|'([^']*)'|([^\s"'>]+))`)
+	headEnd     = regexp.MustCompile(`(?i)</head>|<body`)
+)
+
+var client = &http.Client{Timeout: 10 * time.Second}
+
+// IsKnownHost indica si el dominio es un host de código que no usa meta tags
+func IsKnownHost(domain string) bool {
+	return knownHosts[domain]
+}
+
+// IsPublicHost indica si el dominio es un host vanity público conocido
+func IsPublicHost(domain string) bool {
+	return publicHosts[domain]
+}
+
+// NeedsResolve indica si vale la pena consultar meta tags para el import path:
+// su dominio debe tener un punto y no ser un host de código ni un host vanity
+// público conocido
+func NeedsResolve(importPath string) bool {
+	domain, _, _ := strings.Cut(importPath, "/")
+	return strings.Contains(domain, ".") && !IsKnownHost(domain) && !IsPublicHost(domain)
+}
+
+// Host retorna el dominio real del repositorio
+// Ejemplo: "https://gitlab.com/company/libs/foo.git" -> "gitlab.com"
+func (i *Import) Host() string {
+	u, err := url.Parse(i.repoURL())
+	if err != nil {
+		return ""
+	}
+	return u.Host
+}
+
+// RepoPath retorna el path del repositorio sin el dominio ni .git
+// Ejemplo: "https://gitlab.com/company/libs/foo.git" -> "company/libs/foo"
+func (i *Import) RepoPath() string {
+	u, err := url.Parse(i.repoURL())
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSuffix(strings.Trim(u.Path, "/"), ".git")
+}
+
+// Location retorna host/path del repositorio real
+// Ejemplo: "gitlab.com/company/libs/foo"
+func (i *Import) Location() string {
+	host, repoPath := i.Host(), i.RepoPath()
+	if host == "" || repoPath == "" {
+		return ""
+	}
+	return host + "/" + repoPath
+}
+
+// repoURL retorna la URL del repositorio; si go-import apunta a un proxy
+// (vcs "mod") se usa la URL de go-source
+func (i *Import) repoURL() string {
+	if i.VCS == "mod" {
+		return i.SourceHome
+	}
+	// Formato scp de git: git@host:owner/repo.git
+	if user, rest, ok := strings.Cut(i.RepoURL, "@"); ok && !strings.Contains(user, "/") && !strings.Contains(i.RepoURL, "://") {
+		host, repoPath, _ := strings.Cut(rest, ":")
+		return "ssh://" + host + "/" + repoPath
+	}
+	return i.RepoURL
+}
+
+// Fetch consulta https://<importPath>?go-get=1 y parsea sus meta tags (sin cache)
+// Retorna nil sin error si la página no declara go-import para el path
+func Fetch(importPath string) (*Import, error) {
+	fetchURL := "https://" + importPath + "?go-get=1"
+
+	resp, err := client.Get(fetchURL)
+	if err != nil {
+		return nil, fmt.Errorf("error al consultar %s: %w", fetchURL, err)
+	}
+	defer resp.Body.Close()
+
+	// Igual que el comando go, se parsea el cuerpo aunque el status no sea 200
+	// (algunos servidores responden 404 con las meta tags correctas)
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, fmt.Errorf("error al leer %s: %w", fetchURL, err)
+	}
+
+	return ParseMeta(string(body), importPath)
+}
+
+// ParseMeta busca en el HTML la meta tag go-import cuyo prefijo corresponde
+// al import path y completa la información con go-source
+func ParseMeta(document, importPath string) (*Import, error) {
+	if loc := headEnd.FindStringIndex(document); loc != nil {
+		document = document[:loc[0]]
+	}
+
+	var imports []Import
+	sources := make(map[string]string)
+
+	for _, tag := range metaPattern.FindAllString(document, -1) {
+		attrs := make(map[string]string)
+		for _, m := range attrPattern.FindAllStringSubmatch(tag, -1) {
+			attrs[strings.ToLower(m[1])] = html.UnescapeString(m[2] + m[3] + m[4])
+		}
+
+		fields := strings.Fields(attrs["content"])
+		switch attrs["name"] {
+		case "go-import":
+			if len(fields) == 3 {
+				imports = append(imports, Import{Prefix: fields[0], VCS: fields[1], RepoURL: fields[2]})
+			}
+		case "go-source":
+			if len(fields) >= 2 {
+				sources[fields[0]] = fields[1]
+			}
+		}
+	}
+
+	var match *Import
+	for i := range imports {
+		imp := &imports[i]
+		if importPath != imp.Prefix && !strings.HasPrefix(importPath, imp.Prefix+"/") {
+			continue
+		}
+		// Se prefiere el VCS real sobre "mod" (proxy)
+		if match == nil || (match.VCS == "mod" && imp.VCS != "mod") {
+			match = imp
+			continue
+		}
+		if imp.VCS != "mod" && match.VCS != "mod" && imp.RepoURL != match.RepoURL {
+			return nil, fmt.Errorf("%s declara varias meta tags go-import para %s", imp.Prefix, importPath)
+		}
+	}
+
+	if match == nil {
+		return nil, nil
+	}
+
+	match.SourceHome = sources[match.Prefix]
+	return match, nil
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"errors"
 	"os"
 
 	"github.com/reitmas32/next/cmd/next"
@@ -8,6 +9,10 @@ import (
 
 func main() {
 	if err := next.Execute(); err != nil {
+		var exitErr *next.ExitError
+		if errors.As(err, &exitErr) {
+			os.Exit(exitErr.Code)
+		}
 		os.Exit(1)
 	}
 }